@@ -2,6 +2,7 @@ package v1beta1
 
 import (
 	"bytes"
+	"context"
 	"io"
 )
 
@@ -12,6 +13,35 @@ type ObjectStream interface {
 	io.Writer
 	io.Seeker
 	io.Closer
+	// ReadAt and WriteAt don't use stream offset and are safe for concurrent use
+	io.ReaderAt
+	io.WriterAt
+
+	// Size - object logical size known to the stream, including its own writes
+	Size() int64
+	// Stat - refresh object logical size from the server
+	Stat() (int64, error)
+	// Truncate - set object logical size. Bytes past size are dropped, growing object leaves a hole
+	// reading as zeros. Stream offset is not changed
+	Truncate(size int64) error
+	// Flush - send buffered writes to the server
+	Flush() error
+	// Abort - discard uncommitted writes and close stream. Close commits them
+	Abort() error
+}
+
+// KVTx - key/value transaction owning its own server session.
+// All updates are applied atomically on Commit or discarded on Rollback.
+type KVTx interface {
+	Put(key string, value *bytes.Buffer, contentType string) error
+	Delete(key string) error
+	PutMap(values S3xKVMap) error
+	DeleteMap(values S3xKVMap) error
+	PutJSON(values string) error
+	PutCSV(values string) error
+
+	Commit() error
+	Rollback() error
 }
 
 type S3xClient interface {
@@ -29,9 +59,45 @@ type S3xClient interface {
 
 	// KeyValue Object related operations
 	ObjectHead(bucket, object string) error
+	// ObjectStat - object size, type, layout and metadata
+	ObjectStat(bucket, object string) (ObjectInfo, error)
 	ObjectCreate(bucket, object string, objectType ObjectType, contentType string, chunkSize int, btreeOrder int) error
-	ObjectGetStream(bucket, object string) (ObjectStream, error)
+	// ObjectCreate with user metadata (x-amz-meta-*) attached
+	ObjectCreateWithMetadata(bucket, object string, objectType ObjectType, contentType string, chunkSize int, btreeOrder int, meta map[string]string) error
+	// Replace all user metadata of object, empty meta removes it
+	ObjectSetMetadata(bucket, object string, meta map[string]string) error
+	// User metadata of object with lower case keys
+	ObjectGetMetadata(bucket, object string) (map[string]string, error)
+	// Open object stream, flags select SS_RANDWR (default), SS_APPEND or SS_RDONLY mode
+	ObjectGetStream(bucket, object string, flags ...int) (ObjectStream, error)
 	ObjectDelete(bucket, object string) error
+	// Set stream object logical size, see ObjectStream.Truncate
+	ObjectTruncate(bucket, object string, size int64) error
+	// Server side copy of stream or key/value object, metadata is preserved unless replaced by opts
+	ObjectCopy(srcBucket, srcObject, dstBucket, dstObject string, opts CopyOptions) error
+	// Server side copy to new name followed by source delete
+	ObjectRename(srcBucket, srcObject, dstBucket, dstObject string) error
+
+	// Parallel ranged download of stream object into dst, returns object size
+	ObjectDownload(bucket, object string, dst io.WriterAt, opts TransferOptions) (int64, error)
+	// Parallel upload of size bytes of src into new stream object
+	ObjectUpload(bucket, object string, src io.ReaderAt, size int64, opts TransferOptions) error
+
+	// Whole object S3 PUT/GET without stream session, size -1 if unknown
+	ObjectPut(bucket, object string, r io.Reader, size int64, contentType string, meta map[string]string) error
+	ObjectGet(bucket, object string) (io.ReadCloser, ObjectInfo, error)
+	// Range GET of length bytes at offset, length -1 reads up to the end
+	ObjectGetRange(bucket, object string, offset, length int64) (io.ReadCloser, ObjectInfo, error)
+
+	// S3 multipart upload
+	MultipartInitiate(bucket, object, contentType string, meta map[string]string) (string, error)
+	MultipartUploadPart(bucket, object, uploadID string, partNumber int, r io.Reader, size int64) (Part, error)
+	MultipartComplete(bucket, object, uploadID string, parts []Part) error
+	MultipartAbort(bucket, object, uploadID string) error
+	MultipartListParts(bucket, object, uploadID string) ([]Part, error)
+	MultipartListUploads(bucket, prefix string) ([]MultipartUpload, error)
+	// Concurrent multipart upload of r of unknown length, returns uploaded size
+	ObjectUploadMultipart(bucket, object string, r io.Reader, opts TransferOptions) (int64, error)
 
 	// Single key operations
 	KeyValueGet(bucket, object, key string) (string, error)
@@ -51,5 +117,84 @@ type S3xClient interface {
 	// Transactional methods
 	KeyValueCommit(bucket string, object string) error
 	KeyValueRollback(bucket string, object string) error
-	//Close(bucket, object string) error
+
+	// Explicit key/value transaction
+	BeginKV(bucket, object string) (KVTx, error)
+
+	// Release idle pooled connections
+	Close() error
+}
+
+// S3xClientCtx - context-first variant of S3xClient.
+// Every call is bound to ctx: its deadline and cancellation are propagated
+// into each HTTP request, including stream Read/Write of the returned ObjectStream.
+type S3xClientCtx interface {
+	S3xClient
+
+	// Lists all buckets in system
+	BucketListCtx(ctx context.Context) ([]Bucket, error)
+
+	// Bucket related operations
+	BucketHeadCtx(ctx context.Context, bucket string) error
+	BucketCreateCtx(ctx context.Context, bucket string) error
+	BucketDeleteCtx(ctx context.Context, bucket string) error
+
+	// Lists all objects for specifuc bucket
+	ObjectListCtx(ctx context.Context, bucket, from, pattern string, maxcount int) ([]Object, error)
+
+	// KeyValue Object related operations
+	ObjectHeadCtx(ctx context.Context, bucket, object string) error
+	ObjectStatCtx(ctx context.Context, bucket, object string) (ObjectInfo, error)
+	ObjectCreateCtx(ctx context.Context, bucket, object string, objectType ObjectType, contentType string, chunkSize int, btreeOrder int) error
+	ObjectCreateWithMetadataCtx(ctx context.Context, bucket, object string, objectType ObjectType, contentType string, chunkSize int, btreeOrder int, meta map[string]string) error
+	ObjectSetMetadataCtx(ctx context.Context, bucket, object string, meta map[string]string) error
+	ObjectGetMetadataCtx(ctx context.Context, bucket, object string) (map[string]string, error)
+	ObjectGetStreamCtx(ctx context.Context, bucket, object string, flags ...int) (ObjectStream, error)
+	ObjectDeleteCtx(ctx context.Context, bucket, object string) error
+	ObjectTruncateCtx(ctx context.Context, bucket, object string, size int64) error
+	ObjectCopyCtx(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string, opts CopyOptions) error
+	ObjectRenameCtx(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string) error
+
+	// Parallel ranged download of stream object into dst, returns object size
+	ObjectDownloadCtx(ctx context.Context, bucket, object string, dst io.WriterAt, opts TransferOptions) (int64, error)
+	// Parallel upload of size bytes of src into new stream object
+	ObjectUploadCtx(ctx context.Context, bucket, object string, src io.ReaderAt, size int64, opts TransferOptions) error
+
+	// Whole object S3 PUT/GET without stream session, size -1 if unknown
+	ObjectPutCtx(ctx context.Context, bucket, object string, r io.Reader, size int64, contentType string, meta map[string]string) error
+	ObjectGetCtx(ctx context.Context, bucket, object string) (io.ReadCloser, ObjectInfo, error)
+	// Range GET of length bytes at offset, length -1 reads up to the end
+	ObjectGetRangeCtx(ctx context.Context, bucket, object string, offset, length int64) (io.ReadCloser, ObjectInfo, error)
+
+	// S3 multipart upload
+	MultipartInitiateCtx(ctx context.Context, bucket, object, contentType string, meta map[string]string) (string, error)
+	MultipartUploadPartCtx(ctx context.Context, bucket, object, uploadID string, partNumber int, r io.Reader, size int64) (Part, error)
+	MultipartCompleteCtx(ctx context.Context, bucket, object, uploadID string, parts []Part) error
+	MultipartAbortCtx(ctx context.Context, bucket, object, uploadID string) error
+	MultipartListPartsCtx(ctx context.Context, bucket, object, uploadID string) ([]Part, error)
+	MultipartListUploadsCtx(ctx context.Context, bucket, prefix string) ([]MultipartUpload, error)
+	// Concurrent multipart upload of r of unknown length, returns uploaded size
+	ObjectUploadMultipartCtx(ctx context.Context, bucket, object string, r io.Reader, opts TransferOptions) (int64, error)
+
+	// Single key operations
+	KeyValueGetCtx(ctx context.Context, bucket, object, key string) (string, error)
+	KeyValuePostCtx(ctx context.Context, bucket, object, key string, value *bytes.Buffer, contentType string, more bool) error
+	KeyValueDeleteCtx(ctx context.Context, bucket, object, key string, more bool) error
+
+	// Massive key/value operations
+	KeyValueMapPostCtx(ctx context.Context, bucket, object string, values S3xKVMap, more bool) error
+	KeyValueMapDeleteCtx(ctx context.Context, bucket, object string, values S3xKVMap, more bool) error
+	KeyValuePostJSONCtx(ctx context.Context, bucket, object, values string, more bool) error
+	KeyValuePostCSVCtx(ctx context.Context, bucket, object, values string, more bool) error
+	KeyValueDeleteJSONCtx(ctx context.Context, bucket, object, keyValueJSON string, more bool) error
+
+	// Object's key/value list
+	KeyValueListCtx(ctx context.Context, bucket, object, from, pattern, contentType string, maxcount int, values bool) (string, error)
+
+	// Transactional methods
+	KeyValueCommitCtx(ctx context.Context, bucket string, object string) error
+	KeyValueRollbackCtx(ctx context.Context, bucket string, object string) error
+
+	// Explicit key/value transaction, ctx is used by all transaction requests
+	BeginKVCtx(ctx context.Context, bucket, object string) (KVTx, error)
 }
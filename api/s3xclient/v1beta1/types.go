@@ -2,6 +2,8 @@ package v1beta1
 
 import (
 	"encoding/xml"
+	"hash"
+	"time"
 )
 
 type ObjectType string
@@ -28,6 +30,7 @@ const (
 	SS_RANDWR          int = 0x04
 	SS_KV              int = 0x08
 	SS_STAT            int = 0x10
+	SS_RDONLY          int = 0x20
 	CCOW_O_REPLACE     int = 0x01
 	CCOW_O_CREATE      int = 0x02
 	BYTE_BUFFER        int = 16 * 1024
@@ -41,6 +44,59 @@ var DefaultObjectCreationOption = ObjectCreationOptions{
 	BTreeOrder:  DEFAULT_BTREE_ORDER,
 }
 
+// ObjectInfo - object header fields returned by ObjectStat
+type ObjectInfo struct {
+	Bucket string
+	Object string
+	// Logical size in bytes
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+	ObjectType   ObjectType
+	ChunkSize    int
+	BTreeOrder   int
+	// Version id and generation of the object version
+	VersionID  string
+	Generation uint64
+	// User metadata, keys without x-amz-meta- prefix
+	Metadata map[string]string
+}
+
+// CopyOptions - ObjectCopy settings, source content type and metadata are kept by default
+type CopyOptions struct {
+	// Replaces content type of the copy if not empty
+	ContentType string
+	// Replaces user metadata of the copy if not nil
+	Metadata map[string]string
+}
+
+// NoPartRetries - TransferOptions.PartRetries value disabling part retries, zero selects the default
+const NoPartRetries = -1
+
+// TransferOptions - parallel object transfer settings, zero values select defaults
+type TransferOptions struct {
+	// Part size, rounded up to object chunk size. Default is 8MB
+	PartSize int
+	// Number of parts transferred concurrently. Default is 4
+	Concurrency int
+	// Additional attempts of part failed with transient error. Default is 2, NoPartRetries fails on first error
+	PartRetries int
+	// Expected hex encoded checksum of downloaded data, not verified if empty
+	Checksum string
+	// Checksum algorithm. Default is SHA-256
+	Hash func() hash.Hash
+	// Called after every transferred part with transferred and total bytes, total is -1 if unknown
+	Progress func(transferred, total int64)
+
+	// Uploaded object content type. Default is application/octet-stream
+	ContentType string
+	// Uploaded object user metadata, keys without x-amz-meta- prefix
+	Metadata map[string]string
+	// Uploaded object chunk size. Default is DEFAULT_CHUNKSIZE
+	ChunkSize int
+}
+
 // ListAllMyBucketsResult - bucket list structure
 type ListAllMyBucketsResult struct {
 	XMLName xml.Name `xml:"ListAllMyBucketsResult"`
@@ -73,3 +129,53 @@ type Object struct {
 	LastModified string   `xml:"LastModified"`
 	Size         int      `xml:"Size"`
 }
+
+// InitiateMultipartUploadResult - multipart upload initiation response
+type InitiateMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	UploadID string   `xml:"UploadId"`
+}
+
+// Part - uploaded part of multipart upload
+type Part struct {
+	PartNumber   int    `xml:"PartNumber"`
+	ETag         string `xml:"ETag"`
+	Size         int64  `xml:"Size,omitempty"`
+	LastModified string `xml:"LastModified,omitempty"`
+}
+
+// CompleteMultipartUpload - parts of multipart upload to be assembled into object
+type CompleteMultipartUpload struct {
+	XMLName xml.Name `xml:"CompleteMultipartUpload"`
+	Parts   []Part   `xml:"Part"`
+}
+
+// ListPartsResult - uploaded parts list structure
+type ListPartsResult struct {
+	XMLName              xml.Name `xml:"ListPartsResult"`
+	Bucket               string   `xml:"Bucket"`
+	Key                  string   `xml:"Key"`
+	UploadID             string   `xml:"UploadId"`
+	NextPartNumberMarker int      `xml:"NextPartNumberMarker"`
+	IsTruncated          bool     `xml:"IsTruncated"`
+	Parts                []Part   `xml:"Part"`
+}
+
+// MultipartUpload - multipart upload in progress
+type MultipartUpload struct {
+	Key       string `xml:"Key"`
+	UploadID  string `xml:"UploadId"`
+	Initiated string `xml:"Initiated"`
+}
+
+// ListMultipartUploadsResult - multipart uploads list structure
+type ListMultipartUploadsResult struct {
+	XMLName            xml.Name          `xml:"ListMultipartUploadsResult"`
+	Bucket             string            `xml:"Bucket"`
+	NextKeyMarker      string            `xml:"NextKeyMarker"`
+	NextUploadIDMarker string            `xml:"NextUploadIdMarker"`
+	IsTruncated        bool              `xml:"IsTruncated"`
+	Uploads            []MultipartUpload `xml:"Upload"`
+}
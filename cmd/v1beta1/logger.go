@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+// logrusLogger - adapts logrus entry to v1beta1.Logger key/value interface
+type logrusLogger struct {
+	entry *logrus.Entry
+}
+
+func (l logrusLogger) with(args []any) *logrus.Entry {
+	fields := logrus.Fields{}
+	for i := 0; i+1 < len(args); i += 2 {
+		fields[fmt.Sprint(args[i])] = args[i+1]
+	}
+	return l.entry.WithFields(fields)
+}
+
+func (l logrusLogger) Debug(msg string, args ...any) { l.with(args).Debug(msg) }
+func (l logrusLogger) Info(msg string, args ...any)  { l.with(args).Info(msg) }
+func (l logrusLogger) Warn(msg string, args ...any)  { l.with(args).Warn(msg) }
+func (l logrusLogger) Error(msg string, args ...any) { l.with(args).Error(msg) }
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	v1beta1 "github.com/highpeakdata/edgex-go-connector/pkg/s3xclient/v1beta1"
 	nested "github.com/antonfisher/nested-logrus-formatter"
@@ -19,9 +20,22 @@ var (
 	authKey  = ""
 	secret   = ""
 	endpoint = defaultS3XEndpoint
+	region   = "us-east-1"
 	verbose  = "Info"
+
+	caCert     = ""
+	clientCert = ""
+	clientKey  = ""
 )
 
+// secureEndpoint - endpoint with https scheme
+func secureEndpoint(endpoint string) string {
+	if i := strings.Index(endpoint, "://"); i >= 0 {
+		endpoint = endpoint[i+3:]
+	}
+	return "https://" + endpoint
+}
+
 func main() {
 
 	cmd := &cobra.Command{
@@ -51,15 +65,38 @@ func main() {
 				l.Logger.SetLevel(loggerLevel)
 			}
 
+			options := []v1beta1.EdgexOption{
+				v1beta1.SetSigningRegion(region),
+			}
+			if secure {
+				endpoint = secureEndpoint(endpoint)
+				if caCert != "" {
+					options = append(options, v1beta1.SetCACertFile(caCert))
+				}
+				if clientCert != "" || clientKey != "" {
+					options = append(options, v1beta1.SetClientCertFiles(clientCert, clientKey))
+				}
+			}
+
 			l.Info("HPD S3X service options:")
 			l.Infof("- Secure connection    : '%t'", secure)
 			l.Infof("- S3X service endpoint : '%s'", endpoint)
 			if secure {
 				l.Infof("- Auth key             : '%s'", authKey)
-				l.Infof("- Secret               : '%s'", secret)
+				l.Infof("- Secret               : '%s'", maskSecret(secret))
+				l.Infof("- Signing region       : '%s'", region)
+				l.Infof("- CA certificate       : '%s'", caCert)
+				l.Infof("- Client certificate   : '%s'", clientCert)
+				l.Infof("- Client key           : '%s'", clientKey)
 			}
 
-			_, err = v1beta1.CreateEdgex(endpoint, authKey, secret)
+			s3xLogger := logrusLogger{entry: logger.WithFields(logrus.Fields{
+				"Scope": "S3X",
+			})}
+
+			options = append(options, v1beta1.SetLogger(s3xLogger))
+
+			_, err = v1beta1.CreateEdgex(endpoint, authKey, secret, 0, options...)
 			if err != nil {
 				l.Error(err)
 				os.Exit(1)
@@ -71,7 +108,12 @@ func main() {
 
 	cmd.Flags().StringVarP(&endpoint, "endpoint", "e", endpoint, "S3X service endpoint. Format: `scheme://service-ip:service-port`. Default is "+defaultS3XEndpoint)
 	cmd.Flags().StringVarP(&authKey, "authKey", "k", authKey, "S3X service auth file path")
+	cmd.Flags().StringVarP(&secret, "secret", "", secret, "S3X service secret key used for AWS Signature V4")
+	cmd.Flags().StringVarP(&region, "region", "", region, "AWS Signature V4 signing region")
 	cmd.PersistentFlags().BoolVarP(&secure, "secure", "s", secure, "Use TLS/SSL secure connection")
+	cmd.PersistentFlags().StringVarP(&caCert, "ca-cert", "", caCert, "PEM CA bundle to verify S3X service certificate, used with --secure")
+	cmd.PersistentFlags().StringVarP(&clientCert, "client-cert", "", clientCert, "PEM client certificate for mutual TLS, used with --secure")
+	cmd.PersistentFlags().StringVarP(&clientKey, "client-key", "", clientKey, "PEM client private key for mutual TLS, used with --secure")
 	cmd.PersistentFlags().StringVarP(&verbose, "verbose", "v", verbose, "S3xClient log verbose level")
 
 	if err := cmd.Execute(); err != nil {
@@ -81,3 +123,11 @@ func main() {
 
 	os.Exit(0)
 }
+
+// maskSecret - secret is never logged, only whether it is set
+func maskSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "****"
+}
@@ -59,10 +59,10 @@ func TestMain(m *testing.M) {
 	exitVal := m.Run()
 
 	if config.Mockup == 0 {
-		log.Println("Finalize Edgex connection:")
+		log.Println("Close Edgex connection:")
 		clnt := s3x.(*v1beta1.Edgex)
 		if clnt != nil {
-			clnt.Close(config.Bucket, config.Object)
+			clnt.Close()
 		}
 	} else {
 		log.Println("Close Edgex mockup:")
@@ -0,0 +1,120 @@
+package errors
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// maximum error body size read from S3X service response
+const maxErrorBodySize = 64 * 1024
+
+// APIError - failed S3/S3X request description.
+// Use errors.Is(err, ErrObjectNotExist) etc. to branch on failure kinds.
+type APIError struct {
+	// HTTP response status code
+	StatusCode int
+	// S3 <Error><Code> value, i.e. NoSuchBucket
+	Code string
+	// S3 <Error><Message> value or HTTP status text
+	Message string
+	// x-amz-request-id header or <Error><RequestId> value
+	RequestID string
+
+	// Failed operation name and its target
+	Operation string
+	Bucket    string
+	Object    string
+	Key       string
+}
+
+// s3ErrorResponse - S3 XML error body
+type s3ErrorResponse struct {
+	XMLName   xml.Name `xml:"Error"`
+	Code      string   `xml:"Code"`
+	Message   string   `xml:"Message"`
+	Resource  string   `xml:"Resource"`
+	RequestID string   `xml:"RequestId"`
+}
+
+// NewAPIError - builds APIError from failed response, parsing S3 XML error body if present.
+// Response body is read but not closed.
+func NewAPIError(res *http.Response, operation, bucket, object, key string) *APIError {
+	e := &APIError{
+		StatusCode: res.StatusCode,
+		RequestID:  res.Header.Get("x-amz-request-id"),
+		Operation:  operation,
+		Bucket:     bucket,
+		Object:     object,
+		Key:        key,
+	}
+
+	if res.Body != nil {
+		body, _ := ioutil.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+		var s3err s3ErrorResponse
+		if len(body) > 0 && xml.Unmarshal(body, &s3err) == nil {
+			e.Code = s3err.Code
+			e.Message = s3err.Message
+			if s3err.RequestID != "" {
+				e.RequestID = s3err.RequestID
+			}
+		} else if len(body) > 0 {
+			e.Message = strings.TrimSpace(string(body))
+		}
+	}
+
+	if e.Message == "" {
+		e.Message = http.StatusText(res.StatusCode)
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	target := e.Bucket
+	if e.Object != "" {
+		target += "/" + e.Object
+	}
+	if e.Key != "" {
+		target += " key " + e.Key
+	}
+
+	msg := fmt.Sprintf("%s %s status code: %d", e.Operation, target, e.StatusCode)
+	if e.Code != "" {
+		msg += " " + e.Code
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.RequestID != "" {
+		msg += " (request id " + e.RequestID + ")"
+	}
+	return msg
+}
+
+// Is - maps status code and S3 error code to the package sentinel errors
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBucketNotExist:
+		return e.Code == "NoSuchBucket" || (e.StatusCode == http.StatusNotFound && e.Code == "" && e.Object == "")
+	case ErrObjectNotExist:
+		// NoSuchKey names missing S3 object, even if request was for a key of key/value object
+		return e.Code == "NoSuchKey" ||
+			(e.StatusCode == http.StatusNotFound && e.Code == "" && e.Object != "" && e.Key == "")
+	case ErrKeyNotExist:
+		return e.StatusCode == http.StatusNotFound && e.Key != "" && e.Code != "NoSuchBucket" && e.Code != "NoSuchKey"
+	case ErrBucketExist:
+		return e.Code == "BucketAlreadyExists" || e.Code == "BucketAlreadyOwnedByYou" ||
+			(e.StatusCode == http.StatusConflict && e.Code == "" && e.Object == "")
+	case ErrObjectExist:
+		return e.Code == "ObjectAlreadyExists" ||
+			(e.StatusCode == http.StatusConflict && e.Code == "" && e.Object != "")
+	case ErrUploadNotExist:
+		return e.Code == "NoSuchUpload"
+	case ErrInvalidRange:
+		return e.Code == "InvalidRange" || e.StatusCode == http.StatusRequestedRangeNotSatisfiable
+	}
+	return false
+}
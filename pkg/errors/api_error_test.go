@@ -0,0 +1,60 @@
+package errors
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"X-Amz-Request-Id": []string{"hdr-id"}},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func Test_NewAPIErrorParsesXML(t *testing.T) {
+	body := `<?xml version="1.0" encoding="UTF-8"?>
+<Error><Code>NoSuchBucket</Code><Message>The specified bucket does not exist</Message><RequestId>4442587FB7D0A2F9</RequestId></Error>`
+	err := NewAPIError(newResponse(404, body), "ObjectHead", "bk", "obj", "")
+
+	assert.Equal(t, 404, err.StatusCode)
+	assert.Equal(t, "NoSuchBucket", err.Code)
+	assert.Equal(t, "The specified bucket does not exist", err.Message)
+	assert.Equal(t, "4442587FB7D0A2F9", err.RequestID)
+	assert.True(t, errors.Is(err, ErrBucketNotExist))
+	assert.False(t, errors.Is(err, ErrObjectNotExist))
+	assert.Contains(t, err.Error(), "ObjectHead bk/obj status code: 404 NoSuchBucket")
+}
+
+func Test_APIErrorSentinels(t *testing.T) {
+	cases := []struct {
+		err      *APIError
+		sentinel error
+	}{
+		{NewAPIError(newResponse(404, ""), "BucketHead", "bk", "", ""), ErrBucketNotExist},
+		{NewAPIError(newResponse(404, ""), "ObjectHead", "bk", "obj", ""), ErrObjectNotExist},
+		{NewAPIError(newResponse(404, ""), "KeyValueGet", "bk", "obj", "key1"), ErrKeyNotExist},
+		{NewAPIError(newResponse(404, "<Error><Code>NoSuchKey</Code></Error>"), "KeyValueGet", "bk", "obj", "key1"), ErrObjectNotExist},
+		{NewAPIError(newResponse(409, "<Error><Code>BucketAlreadyOwnedByYou</Code></Error>"), "BucketCreate", "bk", "", ""), ErrBucketExist},
+		{NewAPIError(newResponse(409, ""), "ObjectCreate", "bk", "obj", ""), ErrObjectExist},
+		{NewAPIError(newResponse(409, "<Error><Code>ObjectAlreadyExists</Code></Error>"), "ObjectCreate", "bk", "obj", ""), ErrObjectExist},
+		{NewAPIError(newResponse(409, "<Error><Code>OperationAborted</Code></Error>"), "ObjectCopy", "bk", "obj", ""), nil},
+		{NewAPIError(newResponse(416, ""), "ObjectGet", "bk", "obj", ""), ErrInvalidRange},
+		{NewAPIError(newResponse(404, "<Error><Code>NoSuchUpload</Code></Error>"), "MultipartAbort", "bk", "obj", ""), ErrUploadNotExist},
+	}
+	all := []error{ErrBucketNotExist, ErrObjectNotExist, ErrKeyNotExist, ErrBucketExist, ErrObjectExist, ErrInvalidRange,
+		ErrUploadNotExist}
+
+	for _, c := range cases {
+		for _, sentinel := range all {
+			assert.Equal(t, sentinel == c.sentinel, errors.Is(c.err, sentinel), "%s: %v", c.err.Operation, sentinel)
+		}
+		assert.Equal(t, "hdr-id", c.err.RequestID)
+	}
+}
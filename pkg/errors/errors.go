@@ -3,9 +3,16 @@ package errors
 import "errors"
 
 var (
-	ErrBucketExist    = errors.New("bucket already exists")
-	ErrBucketNotExist = errors.New("bucket does not exist")
-	ErrObjectExist    = errors.New("object already exists")
-	ErrObjectNotExist = errors.New("object does not exist")
-	ErrKeyNotExist    = errors.New("key does not exist")
+	ErrBucketExist     = errors.New("bucket already exists")
+	ErrBucketNotExist  = errors.New("bucket does not exist")
+	ErrObjectExist     = errors.New("object already exists")
+	ErrObjectNotExist  = errors.New("object does not exist")
+	ErrKeyNotExist     = errors.New("key does not exist")
+	ErrTxDone          = errors.New("transaction has already been committed or rolled back")
+	ErrStreamClosed    = errors.New("object stream is closed or aborted")
+	ErrChecksum        = errors.New("object checksum mismatch")
+	ErrInvalidRange    = errors.New("requested range is not satisfiable")
+	ErrUploadNotExist  = errors.New("multipart upload does not exist")
+	ErrStreamMode      = errors.New("operation is not permitted by stream open mode")
+	ErrInvalidArgument = errors.New("invalid argument")
 )
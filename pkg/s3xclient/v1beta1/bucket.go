@@ -1,8 +1,8 @@
 package v1beta1
 
 import (
+	"context"
 	"encoding/xml"
-	"fmt"
 	"io/ioutil"
 	"net/http"
 
@@ -14,6 +14,11 @@ import (
 
 // BucketCreate - create a new bucket
 func (edgex *Edgex) BucketCreate(bucket string) error {
+	return edgex.BucketCreateCtx(context.Background(), bucket)
+}
+
+// BucketCreateCtx - create a new bucket within ctx
+func (edgex *Edgex) BucketCreateCtx(ctx context.Context, bucket string) error {
 
 	bucketPath, err := utils.GetBucketPath(bucket)
 	if err != nil {
@@ -22,25 +27,29 @@ func (edgex *Edgex) BucketCreate(bucket string) error {
 
 	s3xurl := edgex.newS3xURL(bucketPath)
 
-	req, err := http.NewRequest("PUT", s3xurl.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "PUT", s3xurl.String(), nil)
 	if err != nil {
-		fmt.Printf("k/v create bucket error: %v\n", err)
 		return err
 	}
 
-	res, err := edgex.httpClient.Do(req)
+	res, err := edgex.do(req, &operation{name: "BucketCreate", bucket: bucket})
 	if err != nil {
-		fmt.Printf("k/v bucket create error: %v\n", err)
 		return err
 	}
+	defer res.Body.Close()
 	if res.StatusCode < 300 {
 		return nil
 	}
-	return fmt.Errorf("%s bucket create status code: %v", bucketPath, res.StatusCode)
+	return s3xErrors.NewAPIError(res, "BucketCreate", bucket, "", "")
 }
 
 // BucketHead - read bucket header fields
 func (edgex *Edgex) BucketHead(bucket string) error {
+	return edgex.BucketHeadCtx(context.Background(), bucket)
+}
+
+// BucketHeadCtx - read bucket header fields within ctx
+func (edgex *Edgex) BucketHeadCtx(ctx context.Context, bucket string) error {
 
 	bucketPath, err := utils.GetBucketPath(bucket)
 	if err != nil {
@@ -52,23 +61,30 @@ func (edgex *Edgex) BucketHead(bucket string) error {
 		"finalize": "",
 	})
 
-	res, err := http.Head(s3xurl.String())
+	req, err := http.NewRequestWithContext(ctx, "HEAD", s3xurl.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := edgex.do(req, &operation{name: "BucketHead", bucket: bucket})
 	if err != nil {
-		fmt.Printf("Bucket Head error: %v\n", err)
 		return err
 	}
+	defer res.Body.Close()
 
 	if res.StatusCode < 300 {
 		return nil
 	}
-	if res.StatusCode == 404 {
-		return s3xErrors.ErrBucketNotExist
-	}
-	return fmt.Errorf("Bucket %s head error: %v", bucketPath, res)
+	return s3xErrors.NewAPIError(res, "BucketHead", bucket, "", "")
 }
 
 // BucketDelete - delete bucket
 func (edgex *Edgex) BucketDelete(bucket string) error {
+	return edgex.BucketDeleteCtx(context.Background(), bucket)
+}
+
+// BucketDeleteCtx - delete bucket within ctx
+func (edgex *Edgex) BucketDeleteCtx(ctx context.Context, bucket string) error {
 
 	bucketPath, err := utils.GetBucketPath(bucket)
 	if err != nil {
@@ -76,37 +92,40 @@ func (edgex *Edgex) BucketDelete(bucket string) error {
 	}
 	s3xurl := edgex.newS3xURL(bucketPath)
 
-	req, err := http.NewRequest("DELETE", s3xurl.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", s3xurl.String(), nil)
 	if err != nil {
-		fmt.Printf("k/v create bucket delete error: %v\n", err)
 		return err
 	}
 
-	res, err := edgex.httpClient.Do(req)
+	res, err := edgex.do(req, &operation{name: "BucketDelete", bucket: bucket})
 	if err != nil {
-		fmt.Printf("k/v bucket delete error: %v\n", err)
 		return err
 	}
+	defer res.Body.Close()
 	if res.StatusCode < 300 {
 		return nil
 	}
-	return fmt.Errorf("%s bucket delete status code: %v", bucketPath, res.StatusCode)
+	return s3xErrors.NewAPIError(res, "BucketDelete", bucket, "", "")
 }
 
+// BucketList - list all buckets
 func (edgex *Edgex) BucketList() ([]s3xApi.Bucket, error) {
+	return edgex.BucketListCtx(context.Background())
+}
+
+// BucketListCtx - list all buckets within ctx
+func (edgex *Edgex) BucketListCtx(ctx context.Context) ([]s3xApi.Bucket, error) {
 
 	s3xurl := edgex.newS3xURL("")
 
-	req, err := http.NewRequest("GET", s3xurl.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", s3xurl.String(), nil)
 	if err != nil {
-		fmt.Printf("k/v create key/value list error: %v\n", err)
 		return nil, err
 	}
 
 	req.Header.Add("Content-Length", "0")
-	res, err := edgex.httpClient.Do(req)
+	res, err := edgex.do(req, &operation{name: "BucketList"})
 	if err != nil {
-		fmt.Printf("Bucket list error: %v\n", err)
 		return nil, err
 	}
 	defer res.Body.Close()
@@ -115,11 +134,10 @@ func (edgex *Edgex) BucketList() ([]s3xApi.Bucket, error) {
 	if res.StatusCode < 300 {
 		body, err := ioutil.ReadAll(res.Body)
 		if err != nil {
-			fmt.Printf("Object list read error: %v\n", err)
 			return list.Buckets.Buckets, err
 		}
 		err = xml.Unmarshal(body, &list)
 		return list.Buckets.Buckets, err
 	}
-	return list.Buckets.Buckets, fmt.Errorf("Bucket list error: %v", res)
+	return list.Buckets.Buckets, s3xErrors.NewAPIError(res, "BucketList", "", "", "")
 }
@@ -0,0 +1,91 @@
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+	"github.com/highpeakdata/edgex-go-connector/pkg/utils"
+)
+
+// ObjectCopy - server side copy of stream or key/value object.
+// User metadata and content type are preserved unless replaced by opts
+func (edgex *Edgex) ObjectCopy(srcBucket, srcObject, dstBucket, dstObject string, opts s3xApi.CopyOptions) error {
+	return edgex.ObjectCopyCtx(context.Background(), srcBucket, srcObject, dstBucket, dstObject, opts)
+}
+
+// ObjectCopyCtx - server side copy of stream or key/value object within ctx
+func (edgex *Edgex) ObjectCopyCtx(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string, opts s3xApi.CopyOptions) error {
+	srcPath, err := utils.GetObjectPath(srcBucket, srcObject)
+	if err != nil {
+		return err
+	}
+	dstPath, err := utils.GetObjectPath(dstBucket, dstObject)
+	if err != nil {
+		return err
+	}
+
+	// key/value objects are copied with their key/value btree
+	src, err := edgex.ObjectStatCtx(ctx, srcBucket, srcObject)
+	if err != nil {
+		return err
+	}
+
+	s3xurl := edgex.newS3xURL(dstPath)
+	if src.ObjectType == s3xApi.OBJECT_TYPE_KEY_VALUE {
+		s3xurl.AddOptions(S3XURLOptions{
+			"comp": "kv",
+		})
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", s3xurl.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-amz-copy-source", (&url.URL{Path: "/" + srcPath}).EscapedPath())
+	if opts.Metadata != nil || opts.ContentType != "" {
+		// REPLACE drops source headers, so the kept ones are sent again
+		contentType := opts.ContentType
+		if contentType == "" {
+			contentType = src.ContentType
+		}
+		meta := opts.Metadata
+		if meta == nil {
+			meta = src.Metadata
+		}
+		req.Header.Set("x-amz-metadata-directive", "REPLACE")
+		req.Header.Set("Content-Type", contentType)
+		setMetadata(req.Header, meta)
+	} else {
+		req.Header.Set("x-amz-metadata-directive", "COPY")
+	}
+
+	res, err := edgex.do(req, &operation{name: "ObjectCopy", bucket: dstBucket, object: dstObject})
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// copy failure may be reported in 200 OK response body
+	return resultError(res, "ObjectCopy", dstBucket, dstObject)
+}
+
+// ObjectRename - server side copy of object to new name, source object is deleted once copied
+func (edgex *Edgex) ObjectRename(srcBucket, srcObject, dstBucket, dstObject string) error {
+	return edgex.ObjectRenameCtx(context.Background(), srcBucket, srcObject, dstBucket, dstObject)
+}
+
+// ObjectRenameCtx - server side copy of object to new name within ctx, source object is deleted once copied
+func (edgex *Edgex) ObjectRenameCtx(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string) error {
+	if srcBucket == dstBucket && srcObject == dstObject {
+		// deleting the source would remove the only copy
+		return fmt.Errorf("ObjectRename %s/%s onto itself: %w", srcBucket, srcObject, s3xErrors.ErrInvalidArgument)
+	}
+	if err := edgex.ObjectCopyCtx(ctx, srcBucket, srcObject, dstBucket, dstObject, s3xApi.CopyOptions{}); err != nil {
+		return err
+	}
+	return edgex.ObjectDeleteCtx(ctx, srcBucket, srcObject)
+}
@@ -0,0 +1,78 @@
+package v1beta1
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ObjectCopy(t *testing.T) {
+	var requests []*http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		switch r.Method {
+		case "HEAD":
+			if r.URL.Path == "/bk/kv" {
+				w.Header().Set("x-ccow-chunkmap-type", "btree_key_val")
+			}
+			w.Header().Set("Content-Type", "image/png")
+			w.Header().Set("x-amz-meta-camera", "cam-1")
+		case "PUT":
+			if r.URL.Path == "/dst/fail" {
+				w.Write([]byte("<Error><Code>InternalError</Code><Message>copy failed</Message></Error>"))
+				return
+			}
+			w.Write([]byte("<CopyObjectResult><ETag>\"etag\"</ETag></CopyObjectResult>"))
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := CreateEdgex(srv.URL, "", "", 0)
+	assert.Nil(t, err)
+
+	// key/value object is copied with its btree, metadata is kept
+	err = client.ObjectCopy("bk", "kv", "dst", "kv copy", s3xApi.CopyOptions{})
+	assert.Nil(t, err)
+	put := requests[len(requests)-1]
+	assert.Equal(t, "/dst/kv copy", put.URL.Path)
+	assert.Equal(t, "kv", put.URL.Query().Get("comp"))
+	assert.Equal(t, "/bk/kv", put.Header.Get("x-amz-copy-source"))
+	assert.Equal(t, "COPY", put.Header.Get("x-amz-metadata-directive"))
+
+	// replaced content type keeps source metadata
+	err = client.ObjectCopy("bk", "img 1", "dst", "img", s3xApi.CopyOptions{ContentType: "image/jpeg"})
+	assert.Nil(t, err)
+	put = requests[len(requests)-1]
+	assert.Empty(t, put.URL.Query().Get("comp"))
+	assert.Equal(t, "/bk/img%201", put.Header.Get("x-amz-copy-source"))
+	assert.Equal(t, "REPLACE", put.Header.Get("x-amz-metadata-directive"))
+	assert.Equal(t, "image/jpeg", put.Header.Get("Content-Type"))
+	assert.Equal(t, "cam-1", put.Header.Get("x-amz-meta-camera"))
+
+	err = client.ObjectCopy("bk", "img", "dst", "fail", s3xApi.CopyOptions{})
+	var apiErr *s3xErrors.APIError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, "InternalError", apiErr.Code)
+	}
+
+	requests = nil
+	err = client.ObjectRename("bk", "img", "bk", "img2")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"HEAD", "PUT", "DELETE"},
+		[]string{requests[0].Method, requests[1].Method, requests[2].Method})
+	assert.Equal(t, "/bk/img", requests[2].URL.Path)
+
+	// renaming object to itself would delete it
+	requests = nil
+	err = client.ObjectRename("bk", "img", "bk", "img")
+	assert.True(t, errors.Is(err, s3xErrors.ErrInvalidArgument), "unexpected error: %v", err)
+	assert.False(t, errors.Is(err, s3xErrors.ErrObjectExist))
+	assert.Empty(t, requests)
+}
@@ -0,0 +1,51 @@
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingTransport - round tripper counting requests sent through the configured http client
+type countingTransport struct {
+	requests int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func Test_CtxCancelInFlight(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	transport := &countingTransport{}
+	client, err := CreateEdgex(srv.URL, "", "", 0, SetHTTPClient(&http.Client{Transport: transport}))
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+	begin := time.Now()
+	_, err = client.KeyValueGetCtx(ctx, "bk", "obj", "key")
+	assert.True(t, errors.Is(err, context.Canceled), "unexpected error: %v", err)
+	assert.Less(t, time.Since(begin), 5*time.Second)
+	assert.Equal(t, 1, transport.requests)
+}
@@ -0,0 +1,266 @@
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+)
+
+// BalancePolicy - how stateless requests are spread over cluster endpoints
+type BalancePolicy int
+
+const (
+	// BalanceRoundRobin - rotate over healthy endpoints
+	BalanceRoundRobin BalancePolicy = iota
+	// BalanceLeastLatency - prefer healthy endpoint with lowest recent latency
+	BalanceLeastLatency
+)
+
+const (
+	defaultHealthCheckInterval = 10 * time.Second
+	healthCheckTimeout         = 5 * time.Second
+	// weight of the last sample in endpoint latency moving average
+	latencyEWMAWeight = 0.2
+	// session pins not used for this long are dropped, i.e. of transactions never finished
+	sessionPinTTL = 15 * time.Minute
+)
+
+// endpoint - single S3X gateway node
+type endpoint struct {
+	url       *url.URL
+	unhealthy atomic.Bool
+	// moving average latency, nanoseconds. 0 if node was not used yet
+	latency atomic.Int64
+}
+
+func (node *endpoint) observe(latency time.Duration) {
+	for {
+		old := node.latency.Load()
+		avg := int64(latency)
+		if old != 0 {
+			avg = old + int64(latencyEWMAWeight*float64(int64(latency)-old))
+		}
+		if node.latency.CompareAndSwap(old, avg) {
+			return
+		}
+	}
+}
+
+// route - direct request to node
+func (node *endpoint) route(req *http.Request) {
+	req.URL.Scheme = node.url.Scheme
+	req.URL.Host = node.url.Host
+	req.Host = node.url.Host
+}
+
+// endpointPool - cluster endpoints with health state and session affinity
+type endpointPool struct {
+	nodes  []*endpoint
+	policy BalancePolicy
+	next   atomic.Uint64
+
+	// session affinity: session id -> node that created the session
+	lock sync.Mutex
+	pins map[string]*sessionPinned
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// sessionPinned - node of session and last time session was used
+type sessionPinned struct {
+	node *endpoint
+	used time.Time
+}
+
+func newEndpointPool(urls []*url.URL) *endpointPool {
+	pool := &endpointPool{
+		pins: make(map[string]*sessionPinned),
+		stop: make(chan struct{}),
+	}
+	for _, u := range urls {
+		pool.nodes = append(pool.nodes, &endpoint{url: u})
+	}
+	return pool
+}
+
+// sessionPin - session id of request bound to server side session, empty for stateless requests.
+// Request opening a session is balanced, its node is pinned once server returns session id
+func sessionPin(req *http.Request) string {
+	return req.Header.Get("x-session-id")
+}
+
+// pick - node for request: pinned node of its session, otherwise balanced healthy node
+func (pool *endpointPool) pick(pin string) (*endpoint, bool) {
+	if len(pool.nodes) == 1 {
+		return pool.nodes[0], false
+	}
+
+	if pin != "" {
+		pool.lock.Lock()
+		pinned := pool.pins[pin]
+		if pinned != nil {
+			pinned.used = time.Now()
+		}
+		pool.lock.Unlock()
+		if pinned != nil {
+			return pinned.node, true
+		}
+	}
+
+	candidates := make([]*endpoint, 0, len(pool.nodes))
+	for _, node := range pool.nodes {
+		if !node.unhealthy.Load() {
+			candidates = append(candidates, node)
+		}
+	}
+	// all nodes are down: keep trying them in turn
+	if len(candidates) == 0 {
+		candidates = pool.nodes
+	}
+
+	if pool.policy == BalanceLeastLatency {
+		best := candidates[0]
+		for _, node := range candidates[1:] {
+			if node.latency.Load() < best.latency.Load() {
+				best = node
+			}
+		}
+		return best, false
+	}
+	return candidates[pool.next.Add(1)%uint64(len(candidates))], false
+}
+
+// track - update session pins after request completed on node
+func (pool *endpointPool) track(pin string, node *endpoint, req *http.Request, res *http.Response) {
+	if len(pool.nodes) == 1 {
+		return
+	}
+
+	q := req.URL.Query()
+	_, finalize := q["finalize"]
+	_, cancel := q["cancel"]
+
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+	if finalize || cancel {
+		if pin != "" {
+			delete(pool.pins, pin)
+		}
+		return
+	}
+	if res.StatusCode >= 300 {
+		return
+	}
+	if sid := res.Header.Get("X-Session-Id"); sid != "" && pool.pins[sid] == nil {
+		pool.expire()
+		pool.pins[sid] = &sessionPinned{node: node, used: time.Now()}
+	}
+}
+
+// expire - drop pins of sessions idle longer than sessionPinTTL, called with lock held
+func (pool *endpointPool) expire() {
+	deadline := time.Now().Add(-sessionPinTTL)
+	for sid, pinned := range pool.pins {
+		if pinned.used.Before(deadline) {
+			delete(pool.pins, sid)
+		}
+	}
+}
+
+// isDialError - request never reached the node, so it is safe to send it elsewhere
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// healthCheck - probe all nodes every interval until pool is closed
+func (edgex *Edgex) healthCheck(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-edgex.endpoints.stop:
+			return
+		case <-ticker.C:
+		}
+
+		var wg sync.WaitGroup
+		for _, node := range edgex.endpoints.nodes {
+			wg.Add(1)
+			go func(node *endpoint) {
+				defer wg.Done()
+				edgex.probe(node)
+			}(node)
+		}
+		wg.Wait()
+	}
+}
+
+// probe - node is healthy if it answers HTTP at all, server errors excluded
+func (edgex *Edgex) probe(node *endpoint) {
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "HEAD", node.url.String()+"/", nil)
+	if err != nil {
+		return
+	}
+	start := time.Now()
+	res, err := edgex.httpClient.Do(req)
+	healthy := err == nil && res.StatusCode < 500
+	if err == nil {
+		res.Body.Close()
+		node.observe(time.Since(start))
+	}
+
+	if node.unhealthy.Swap(!healthy) == healthy {
+		if healthy {
+			edgex.logger.Info("s3x endpoint is up", "endpoint", node.url.Host)
+		} else {
+			edgex.logger.Warn("s3x endpoint is down", "endpoint", node.url.Host, "error", err)
+		}
+	}
+}
+
+// SetBalancePolicy - how stateless requests are spread over CreateEdgexCluster endpoints
+func SetBalancePolicy(policy BalancePolicy) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.balancePolicy = policy
+	}
+}
+
+// SetHealthCheckInterval - period of background endpoint health checks, 0 disables them.
+// Unreachable endpoints are also marked down on connection failures
+func SetHealthCheckInterval(interval time.Duration) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.healthCheckInterval = interval
+	}
+}
+
+// CreateEdgexCluster - S3X client of several gateway nodes of one cluster.
+// Stateless calls are balanced over healthy nodes and fail over on connection errors,
+// k/v and stream sessions stay on the node that created them
+func CreateEdgexCluster(s3xurls []string, authkey, secret string, debug int, options ...EdgexOption) (s3xApi.S3xClientCtx, error) {
+	if len(s3xurls) == 0 {
+		return nil, errors.New("no S3X endpoints")
+	}
+
+	urls := make([]*url.URL, 0, len(s3xurls))
+	for _, s3xurl := range s3xurls {
+		u, err := getValidUrl(strings.TrimSpace(s3xurl))
+		if err != nil {
+			return nil, err
+		}
+		urls = append(urls, u)
+	}
+	return createEdgex(urls, authkey, secret, debug, options...)
+}
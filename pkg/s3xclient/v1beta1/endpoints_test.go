@@ -0,0 +1,108 @@
+package v1beta1
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// nodeServer counts requests served by one gateway node
+type nodeServer struct {
+	lock     sync.Mutex
+	requests int
+	sessions *sessionServer
+}
+
+func (s *nodeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	s.requests++
+	s.lock.Unlock()
+	s.sessions.ServeHTTP(w, r)
+}
+
+func (s *nodeServer) count() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.requests
+}
+
+func Test_ClusterFailoverAndAffinity(t *testing.T) {
+	sessions := &sessionServer{keys: map[string]string{}, committed: map[string]bool{}}
+	nodeA := &nodeServer{sessions: sessions}
+	nodeB := &nodeServer{sessions: sessions}
+	srvA := httptest.NewServer(nodeA)
+	defer srvA.Close()
+	srvB := httptest.NewServer(nodeB)
+	defer srvB.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	client, err := CreateEdgexCluster([]string{down.URL, srvA.URL, srvB.URL}, "", "", 0,
+		SetHealthCheckInterval(0))
+	assert.Nil(t, err)
+	defer client.Close()
+
+	// stateless calls fail over from dead node and are balanced over live ones
+	for i := 0; i < 6; i++ {
+		assert.Nil(t, client.BucketHead("bk"))
+	}
+	assert.Equal(t, 6, nodeA.count()+nodeB.count())
+	assert.True(t, nodeA.count() > 0 && nodeB.count() > 0)
+
+	// all requests of transaction go to node that opened its session
+	a, b := nodeA.count(), nodeB.count()
+	tx, err := client.BeginKV("bk", "obj")
+	assert.Nil(t, err)
+	for _, key := range []string{"k1", "k2", "k3"} {
+		assert.Nil(t, tx.Put(key, bytes.NewBufferString("v"), ""))
+	}
+	assert.Nil(t, tx.Commit())
+	assert.True(t, (nodeA.count() == a+4 && nodeB.count() == b) || (nodeA.count() == a && nodeB.count() == b+4))
+}
+
+func Test_ClusterSessionPins(t *testing.T) {
+	sessions := &sessionServer{keys: map[string]string{}, committed: map[string]bool{}}
+	srvA := httptest.NewServer(&nodeServer{sessions: sessions})
+	defer srvA.Close()
+	srvB := httptest.NewServer(&nodeServer{sessions: sessions})
+	defer srvB.Close()
+
+	client, err := CreateEdgexCluster([]string{srvA.URL, srvB.URL}, "", "", 0, SetHealthCheckInterval(0))
+	assert.Nil(t, err)
+	defer client.Close()
+	pool := client.(*Edgex).endpoints
+	pins := func() int {
+		pool.lock.Lock()
+		defer pool.lock.Unlock()
+		return len(pool.pins)
+	}
+
+	// pin is dropped once transaction is finished
+	tx, err := client.BeginKV("bk", "obj")
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Put("k1", bytes.NewBufferString("v"), ""))
+	assert.Equal(t, 1, pins())
+	assert.Nil(t, tx.Commit())
+	assert.Equal(t, 0, pins())
+
+	// pin of transaction never finished ages out when next session is opened
+	abandoned, err := client.BeginKV("bk", "obj")
+	assert.Nil(t, err)
+	assert.Nil(t, abandoned.Put("k2", bytes.NewBufferString("v"), ""))
+	pool.lock.Lock()
+	for _, pinned := range pool.pins {
+		pinned.used = time.Now().Add(-2 * sessionPinTTL)
+	}
+	pool.lock.Unlock()
+	tx, err = client.BeginKV("bk", "obj")
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Put("k3", bytes.NewBufferString("v"), ""))
+	assert.Equal(t, 1, pins())
+	assert.Nil(t, tx.Rollback())
+	assert.Equal(t, 0, pins())
+}
@@ -2,13 +2,16 @@ package v1beta1
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
+	"errors"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"strconv"
 
 	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
 	"github.com/highpeakdata/edgex-go-connector/pkg/utils"
 )
 
@@ -18,6 +21,11 @@ func addKey(req string, key string) string {
 
 // KeyValueGet - read object value field
 func (edgex *Edgex) KeyValueGet(bucket, object, key string) (string, error) {
+	return edgex.KeyValueGetCtx(context.Background(), bucket, object, key)
+}
+
+// KeyValueGetCtx - read object value field within ctx
+func (edgex *Edgex) KeyValueGetCtx(ctx context.Context, bucket, object, key string) (string, error) {
 	objectPath, err := utils.GetObjectPath(bucket, object)
 	if err != nil {
 		return "", err
@@ -29,40 +37,44 @@ func (edgex *Edgex) KeyValueGet(bucket, object, key string) (string, error) {
 	})
 
 	rq := addKey(s3xurl.String(), key)
-	if edgex.Debug > 0 {
-		fmt.Printf("KeyValueGet request: %s\n", rq)
+	req, err := http.NewRequestWithContext(ctx, "GET", rq, nil)
+	if err != nil {
+		return "", err
 	}
-	res, err := http.Get(rq)
+
+	res, err := edgex.do(req, &operation{name: "KeyValueGet", bucket: bucket, object: object, key: key})
 	if err != nil {
-		fmt.Printf("Object Get error: %v\n", err)
 		return "", err
 	}
 
 	defer res.Body.Close()
-	if edgex.Debug > 0 {
-		fmt.Printf("KeyValueGet response: %+v\n", res)
-	}
 
 	if res.StatusCode < 300 {
 		body, err := ioutil.ReadAll(res.Body)
 		if err != nil {
-			fmt.Printf("Object Get read error: %v\n", err)
 			return "", err
 		}
 		return string(body), nil
 	}
-	if res.StatusCode == 404 {
-		return "", fmt.Errorf("Object %s not found", objectPath)
+	apiErr := s3xErrors.NewAPIError(res, "KeyValueGet", bucket, object, key)
+	if apiErr.StatusCode == http.StatusNotFound && apiErr.Code == "" {
+		// bare 404 doesn't tell missing key from missing object
+		if err := edgex.ObjectHeadCtx(ctx, bucket, object); err != nil &&
+			(errors.Is(err, s3xErrors.ErrObjectNotExist) || errors.Is(err, s3xErrors.ErrBucketNotExist)) {
+			return "", err
+		}
 	}
-	return "", fmt.Errorf("Object %s get error: %v", objectPath, res)
+	return "", apiErr
 }
 
-// KeyValuePost - post key/value pairs
-func (edgex *Edgex) KeyValuePost(bucket, object, key string, value *bytes.Buffer, contentType string, more bool) error {
+// keyValueUpdate - post/delete k/v pairs within session sid, returns session id reported by server.
+// Empty sid starts a new session, more=false commits session with this request.
+func (edgex *Edgex) keyValueUpdate(ctx context.Context, op, method, bucket, object, key string,
+	body []byte, contentType string, more bool, sid string) (string, error) {
 
 	objectPath, err := utils.GetObjectPath(bucket, object)
 	if err != nil {
-		return err
+		return sid, err
 	}
 
 	s3xurl := edgex.newS3xURL(objectPath)
@@ -81,367 +93,143 @@ func (edgex *Edgex) KeyValuePost(bucket, object, key string, value *bytes.Buffer
 		})
 	}
 
-	rq := addKey(s3xurl.String(), key)
-	if edgex.Debug > 0 {
-		fmt.Printf("KeyValuePost request: %s\n", rq)
+	rq := s3xurl.String()
+	if key != "" {
+		rq = addKey(rq, key)
 	}
 
-	req, err := http.NewRequest("POST", rq, value)
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rq, reader)
 	if err != nil {
-		fmt.Printf("k/v create key/value post error: %v\n", err)
-		return err
+		return sid, err
 	}
 
-	if contentType != "" {
-		req.Header.Add("Content-Type", contentType)
-	} else {
-		req.Header.Add("Content-Type", "application/octet-stream")
+	req.Header.Add("Content-Type", contentType)
+	req.Header.Add("Content-Length", strconv.Itoa(len(body)))
+	if sid != "" {
+		req.Header.Add("x-session-id", sid)
 	}
-	req.Header.Add("Content-Length", strconv.Itoa(value.Len()))
-	if edgex.Sid != "" {
-		req.Header.Add("x-session-id", edgex.Sid)
+
+	info := &operation{name: op, bucket: bucket, object: object, key: key, session: sid}
+	if edgex.tracer != nil {
+		info.keys = kvKeyCount(key, body, contentType)
 	}
-	fmt.Printf("KeyValuePost request: %+v\n", req)
-	res, err := edgex.httpClient.Do(req)
+	res, err := edgex.do(req, info)
 	if err != nil {
-		fmt.Printf("k/v post error: %v\n", err)
-		return err
+		return sid, err
 	}
 	defer res.Body.Close()
-	fmt.Printf("KeyValuePost response: %+v\n", res)
+
 	if res.StatusCode < 300 {
-		sid := res.Header.Get("X-Session-Id")
-		edgex.Sid = sid
-		return nil
+		return res.Header.Get("X-Session-Id"), nil
 	}
-	return fmt.Errorf("%s post status code: %v", objectPath, res.StatusCode)
+	return sid, s3xErrors.NewAPIError(res, op, bucket, object, key)
 }
 
-// KeyValuePostMap - post key/value map in JSON format
-func (edgex *Edgex) KeyValueMapPost(bucket, object string, values s3xApi.S3xKVMap, more bool) error {
-	objectPath, err := utils.GetObjectPath(bucket, object)
+// keyValueSessionUpdate - keyValueUpdate within implicit per object session
+// used by methods without explicit transaction handle
+func (edgex *Edgex) keyValueSessionUpdate(ctx context.Context, op, method, bucket, object, key string,
+	body []byte, contentType string, more bool) error {
+	sid, err := edgex.keyValueUpdate(ctx, op, method, bucket, object, key, body, contentType, more,
+		edgex.sessions.get(bucket, object))
 	if err != nil {
 		return err
 	}
+	edgex.sessions.set(bucket, object, sid)
+	return nil
+}
 
-	s3xurl := edgex.newS3xURL(objectPath)
-	s3xurl.AddOptions(S3XURLOptions{
-		"comp": "kv",
-	})
-
-	if !more {
-		s3xurl.AddOptions(S3XURLOptions{
-			"x-ccow-autocommit": "1",
-			"finalize":          "",
-		})
-	} else {
-		s3xurl.AddOptions(S3XURLOptions{
-			"x-ccow-autocommit": "0",
-		})
-	}
-	jsonBytes, err := json.Marshal(values)
-	if err != nil {
-		return err
-	}
+// KeyValuePost - post key/value pairs
+func (edgex *Edgex) KeyValuePost(bucket, object, key string, value *bytes.Buffer, contentType string, more bool) error {
+	return edgex.KeyValuePostCtx(context.Background(), bucket, object, key, value, contentType, more)
+}
 
-	req, err := http.NewRequest("POST", s3xurl.String(), bytes.NewBuffer(jsonBytes))
-	if err != nil {
-		fmt.Printf("k/v create key/value json post error: %v\n", err)
-		return err
+// KeyValuePostCtx - post key/value pairs within ctx
+func (edgex *Edgex) KeyValuePostCtx(ctx context.Context, bucket, object, key string, value *bytes.Buffer, contentType string, more bool) error {
+	if contentType == "" {
+		contentType = "application/octet-stream"
 	}
+	return edgex.keyValueSessionUpdate(ctx, "KeyValuePost", "POST", bucket, object, key, value.Bytes(), contentType, more)
+}
 
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Content-Length", strconv.Itoa(len(jsonBytes)))
-	if edgex.Sid != "" {
-		req.Header.Add("x-session-id", edgex.Sid)
-	}
+// KeyValuePostMap - post key/value map in JSON format
+func (edgex *Edgex) KeyValueMapPost(bucket, object string, values s3xApi.S3xKVMap, more bool) error {
+	return edgex.KeyValueMapPostCtx(context.Background(), bucket, object, values, more)
+}
 
-	if edgex.Debug > 0 {
-		fmt.Printf("KeyValuePostMap request: %+v\n", req)
-	}
-	res, err := edgex.httpClient.Do(req)
+// KeyValueMapPostCtx - post key/value map in JSON format within ctx
+func (edgex *Edgex) KeyValueMapPostCtx(ctx context.Context, bucket, object string, values s3xApi.S3xKVMap, more bool) error {
+	jsonBytes, err := json.Marshal(values)
 	if err != nil {
-		fmt.Printf("k/v json post error: %v\n", err)
 		return err
 	}
-	defer res.Body.Close()
-
-	if edgex.Debug > 0 {
-		fmt.Printf("KeyValuePostMap response: %+v\n", res)
-	}
-
-	if res.StatusCode < 300 {
-		sid := res.Header.Get("X-Session-Id")
-		edgex.Sid = sid
-		return nil
-	}
-	return fmt.Errorf("%s json post status code: %v", objectPath, res.StatusCode)
+	return edgex.keyValueSessionUpdate(ctx, "KeyValueMapPost", "POST", bucket, object, "", jsonBytes, "application/json", more)
 }
 
 // KeyValueMapDelete - delete key/value map in JSON format
 func (edgex *Edgex) KeyValueMapDelete(bucket, object string, values s3xApi.S3xKVMap, more bool) error {
-	objectPath, err := utils.GetObjectPath(bucket, object)
-	if err != nil {
-		return err
-	}
-	s3xurl := edgex.newS3xURL(objectPath)
-	s3xurl.AddOptions(S3XURLOptions{
-		"comp": "kv",
-	})
-
-	if !more {
-		s3xurl.AddOptions(S3XURLOptions{
-			"x-ccow-autocommit": "1",
-			"finalize":          "",
-		})
-	} else {
-		s3xurl.AddOptions(S3XURLOptions{
-			"x-ccow-autocommit": "0",
-		})
-	}
+	return edgex.KeyValueMapDeleteCtx(context.Background(), bucket, object, values, more)
+}
 
+// KeyValueMapDeleteCtx - delete key/value map in JSON format within ctx
+func (edgex *Edgex) KeyValueMapDeleteCtx(ctx context.Context, bucket, object string, values s3xApi.S3xKVMap, more bool) error {
 	jsonBytes, err := json.Marshal(values)
 	if err != nil {
 		return err
 	}
-
-	req, err := http.NewRequest("DELETE", s3xurl.String(), bytes.NewBuffer(jsonBytes))
-	if err != nil {
-		fmt.Printf("k/v delete key/value json post error: %v\n", err)
-		return err
-	}
-
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Content-Length", strconv.Itoa(len(jsonBytes)))
-	if edgex.Sid != "" {
-		req.Header.Add("x-session-id", edgex.Sid)
-	}
-	res, err := edgex.httpClient.Do(req)
-	if err != nil {
-		fmt.Printf("k/v json delete error: %v\n", err)
-		return err
-	}
-	defer res.Body.Close()
-
-	if res.StatusCode < 300 {
-		sid := res.Header.Get("X-Session-Id")
-		edgex.Sid = sid
-		return nil
-	}
-	return fmt.Errorf("%s json delete status code: %v", objectPath, res.StatusCode)
+	return edgex.keyValueSessionUpdate(ctx, "KeyValueMapDelete", "DELETE", bucket, object, "", jsonBytes, "application/json", more)
 }
 
 // KeyValuePostJSON - post key/value pairs
 func (edgex *Edgex) KeyValuePostJSON(bucket, object, keyValueJSON string, more bool) error {
-	objectPath, err := utils.GetObjectPath(bucket, object)
-	if err != nil {
-		return err
-	}
-
-	s3xurl := edgex.newS3xURL(objectPath)
-	s3xurl.AddOptions(S3XURLOptions{
-		"comp": "kv",
-	})
-
-	if !more {
-		s3xurl.AddOptions(S3XURLOptions{
-			"x-ccow-autocommit": "1",
-			"finalize":          "",
-		})
-	} else {
-		s3xurl.AddOptions(S3XURLOptions{
-			"x-ccow-autocommit": "0",
-		})
-	}
-
-	req, err := http.NewRequest("POST", s3xurl.String(), bytes.NewBufferString(keyValueJSON))
-	if err != nil {
-		fmt.Printf("k/v create key/value json post error: %v\n", err)
-		return err
-	}
-
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Content-Length", strconv.Itoa(len(keyValueJSON)))
-	if edgex.Sid != "" {
-		req.Header.Add("x-session-id", edgex.Sid)
-	}
-
-	if edgex.Debug > 0 {
-		fmt.Printf("KeyValuePostJSON request: %+v\n", req)
-	}
-	res, err := edgex.httpClient.Do(req)
-	if err != nil {
-		fmt.Printf("k/v json post error: %v\n", err)
-		return err
-	}
-	defer res.Body.Close()
-
-	if edgex.Debug > 0 {
-		fmt.Printf("KeyValuePostJSON response: %+v\n", res)
-	}
+	return edgex.KeyValuePostJSONCtx(context.Background(), bucket, object, keyValueJSON, more)
+}
 
-	if res.StatusCode < 300 {
-		sid := res.Header.Get("X-Session-Id")
-		edgex.Sid = sid
-		return nil
-	}
-	return fmt.Errorf("%s json post status code: %v", objectPath, res.StatusCode)
+// KeyValuePostJSONCtx - post key/value pairs within ctx
+func (edgex *Edgex) KeyValuePostJSONCtx(ctx context.Context, bucket, object, keyValueJSON string, more bool) error {
+	return edgex.keyValueSessionUpdate(ctx, "KeyValuePostJSON", "POST", bucket, object, "", []byte(keyValueJSON), "application/json", more)
 }
 
 // KeyValuePostCSV - post key/value pairs presented like csv
 func (edgex *Edgex) KeyValuePostCSV(bucket, object, keyValueCSV string, more bool) error {
+	return edgex.KeyValuePostCSVCtx(context.Background(), bucket, object, keyValueCSV, more)
+}
 
-	objectPath, err := utils.GetObjectPath(bucket, object)
-	if err != nil {
-		return err
-	}
-
-	s3xurl := edgex.newS3xURL(objectPath)
-	s3xurl.AddOptions(S3XURLOptions{
-		"comp": "kv",
-	})
-
-	if !more {
-		s3xurl.AddOptions(S3XURLOptions{
-			"x-ccow-autocommit": "1",
-			"finalize":          "",
-		})
-	} else {
-		s3xurl.AddOptions(S3XURLOptions{
-			"x-ccow-autocommit": "0",
-		})
-	}
-
-	req, err := http.NewRequest("POST", s3xurl.String(), bytes.NewBufferString(keyValueCSV))
-	if err != nil {
-		fmt.Printf("k/v create key/value csv post error: %v\n", err)
-		return err
-	}
-
-	req.Header.Add("Content-Type", "text/csv")
-	req.Header.Add("Content-Length", strconv.Itoa(len(keyValueCSV)))
-	if edgex.Sid != "" {
-		req.Header.Add("x-session-id", edgex.Sid)
-	}
-	res, err := edgex.httpClient.Do(req)
-	if err != nil {
-		fmt.Printf("k/v csv post error: %v\n", err)
-		return err
-	}
-	defer res.Body.Close()
-
-	if res.StatusCode < 300 {
-		sid := res.Header.Get("X-Session-Id")
-		edgex.Sid = sid
-		return nil
-	}
-	return fmt.Errorf("%s csv post status code: %v", objectPath, res.StatusCode)
+// KeyValuePostCSVCtx - post key/value pairs presented like csv within ctx
+func (edgex *Edgex) KeyValuePostCSVCtx(ctx context.Context, bucket, object, keyValueCSV string, more bool) error {
+	return edgex.keyValueSessionUpdate(ctx, "KeyValuePostCSV", "POST", bucket, object, "", []byte(keyValueCSV), "text/csv", more)
 }
 
 // KeyValueDelete - delete key/value pair
 func (edgex *Edgex) KeyValueDelete(bucket, object, key string, more bool) error {
-	objectPath, err := utils.GetObjectPath(bucket, object)
-	if err != nil {
-		return err
-	}
-	s3xurl := edgex.newS3xURL(objectPath)
-	s3xurl.AddOptions(S3XURLOptions{
-		"comp": "kv",
-	})
-
-	if !more {
-		s3xurl.AddOptions(S3XURLOptions{
-			"x-ccow-autocommit": "1",
-			"finalize":          "",
-		})
-	} else {
-		s3xurl.AddOptions(S3XURLOptions{
-			"x-ccow-autocommit": "0",
-		})
-	}
-
-	rq := addKey(s3xurl.String(), key)
-	if edgex.Debug > 0 {
-		fmt.Printf("KeyValueDelete request: %s\n", rq)
-	}
-
-	req, err := http.NewRequest("DELETE", rq, nil)
-	if err != nil {
-		fmt.Printf("k/v delete key/value error: %v\n", err)
-		return err
-	}
-
-	req.Header.Add("Content-Type", "application/octet-stream")
-	req.Header.Add("Content-Length", "0")
-	if edgex.Sid != "" {
-		req.Header.Add("x-session-id", edgex.Sid)
-	}
-	res, err := edgex.httpClient.Do(req)
-	if err != nil {
-		fmt.Printf("k/v delete error: %v\n", err)
-		return err
-	}
-	defer res.Body.Close()
+	return edgex.KeyValueDeleteCtx(context.Background(), bucket, object, key, more)
+}
 
-	if res.StatusCode < 300 {
-		sid := res.Header.Get("X-Session-Id")
-		edgex.Sid = sid
-		return nil
-	}
-	return fmt.Errorf("%s delete status code: %v", objectPath, res.StatusCode)
+// KeyValueDeleteCtx - delete key/value pair within ctx
+func (edgex *Edgex) KeyValueDeleteCtx(ctx context.Context, bucket, object, key string, more bool) error {
+	return edgex.keyValueSessionUpdate(ctx, "KeyValueDelete", "DELETE", bucket, object, key, nil, "application/octet-stream", more)
 }
 
 // KeyValueDeleteJSON - delete key/value pairs defined by json
 func (edgex *Edgex) KeyValueDeleteJSON(bucket, object, keyValueJSON string, more bool) error {
+	return edgex.KeyValueDeleteJSONCtx(context.Background(), bucket, object, keyValueJSON, more)
+}
 
-	objectPath, err := utils.GetObjectPath(bucket, object)
-	if err != nil {
-		return err
-	}
-	s3xurl := edgex.newS3xURL(objectPath)
-	s3xurl.AddOptions(S3XURLOptions{
-		"comp": "kv",
-	})
-
-	if !more {
-		s3xurl.AddOptions(S3XURLOptions{
-			"x-ccow-autocommit": "1",
-			"finalize":          "",
-		})
-	} else {
-		s3xurl.AddOptions(S3XURLOptions{
-			"x-ccow-autocommit": "0",
-		})
-	}
-
-	req, err := http.NewRequest("DELETE", s3xurl.String(), bytes.NewBufferString(keyValueJSON))
-	if err != nil {
-		fmt.Printf("k/v create key/value json post error: %v\n", err)
-		return err
-	}
-
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Content-Length", strconv.Itoa(len(keyValueJSON)))
-	if edgex.Sid != "" {
-		req.Header.Add("x-session-id", edgex.Sid)
-	}
-	res, err := edgex.httpClient.Do(req)
-	if err != nil {
-		fmt.Printf("k/v json delete error: %v\n", err)
-		return err
-	}
-	defer res.Body.Close()
-
-	if res.StatusCode < 300 {
-		sid := res.Header.Get("X-Session-Id")
-		edgex.Sid = sid
-		return nil
-	}
-	return fmt.Errorf("%s json delete status code: %v", objectPath, res.StatusCode)
+// KeyValueDeleteJSONCtx - delete key/value pairs defined by json within ctx
+func (edgex *Edgex) KeyValueDeleteJSONCtx(ctx context.Context, bucket, object, keyValueJSON string, more bool) error {
+	return edgex.keyValueSessionUpdate(ctx, "KeyValueDeleteJSON", "DELETE", bucket, object, "", []byte(keyValueJSON), "application/json", more)
 }
 
 // KeyValueList - read key/value pairs, contentType: application/json or text/csv
 func (edgex *Edgex) KeyValueList(bucket, object, from, pattern, contentType string, maxcount int, values bool) (string, error) {
+	return edgex.KeyValueListCtx(context.Background(), bucket, object, from, pattern, contentType, maxcount, values)
+}
+
+// KeyValueListCtx - read key/value pairs within ctx
+func (edgex *Edgex) KeyValueListCtx(ctx context.Context, bucket, object, from, pattern, contentType string, maxcount int, values bool) (string, error) {
 
 	objectPath, err := utils.GetObjectPath(bucket, object)
 	if err != nil {
@@ -474,21 +262,15 @@ func (edgex *Edgex) KeyValueList(bucket, object, from, pattern, contentType stri
 		rq = rq + "&pattern=" + pattern
 	}
 
-	if edgex.Debug > 0 {
-		fmt.Printf("KeyValueList request: %s\n", rq)
-	}
-
-	req, err := http.NewRequest("GET", rq, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", rq, nil)
 	if err != nil {
-		fmt.Printf("k/v create key/value list error: %v\n", err)
 		return "", err
 	}
 
 	req.Header.Add("Content-Type", contentType)
 	req.Header.Add("Content-Length", "0")
-	res, err := edgex.httpClient.Do(req)
+	res, err := edgex.do(req, &operation{name: "KeyValueList", bucket: bucket, object: object})
 	if err != nil {
-		fmt.Printf("k/v list error: %v\n", err)
 		return "", err
 	}
 	defer res.Body.Close()
@@ -496,13 +278,9 @@ func (edgex *Edgex) KeyValueList(bucket, object, from, pattern, contentType stri
 	if res.StatusCode < 300 {
 		body, err := ioutil.ReadAll(res.Body)
 		if err != nil {
-			fmt.Printf("Object key/value list read error: %v\n", err)
 			return "", err
 		}
 		return string(body), nil
 	}
-	if res.StatusCode == 404 {
-		return "", fmt.Errorf("Object %s not found", objectPath)
-	}
-	return "", fmt.Errorf("Object %s list error: %v", objectPath, res)
+	return "", s3xErrors.NewAPIError(res, "KeyValueList", bucket, object, "")
 }
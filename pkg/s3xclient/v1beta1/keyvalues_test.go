@@ -0,0 +1,33 @@
+package v1beta1
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_KeyValueGetNotFound(t *testing.T) {
+	// gateway answers bare 404 for both missing object and missing key
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "HEAD" && r.URL.Path == "/bk/obj" {
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	client, err := CreateEdgex(srv.URL, "", "", 0)
+	assert.Nil(t, err)
+
+	_, err = client.KeyValueGet("bk", "obj", "missing")
+	assert.True(t, errors.Is(err, s3xErrors.ErrKeyNotExist), "unexpected error: %v", err)
+	assert.False(t, errors.Is(err, s3xErrors.ErrObjectNotExist))
+
+	_, err = client.KeyValueGet("bk", "nobj", "key")
+	assert.True(t, errors.Is(err, s3xErrors.ErrObjectNotExist), "unexpected error: %v", err)
+	assert.False(t, errors.Is(err, s3xErrors.ErrKeyNotExist))
+}
@@ -0,0 +1,67 @@
+package v1beta1
+
+import (
+	"log/slog"
+	"os"
+)
+
+// Logger - leveled structured logger used by Edgex.
+// args are alternating key/value pairs, so *slog.Logger satisfies it as is.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// nopLogger - default silent logger
+type nopLogger struct{}
+
+func (nopLogger) Debug(msg string, args ...any) {}
+func (nopLogger) Info(msg string, args ...any)  {}
+func (nopLogger) Warn(msg string, args ...any)  {}
+func (nopLogger) Error(msg string, args ...any) {}
+
+// SetLogger - route client logs to logger. Client is silent by default
+func SetLogger(logger Logger) EdgexOption {
+	return func(edgex *Edgex) {
+		if logger == nil {
+			logger = nopLogger{}
+		}
+		edgex.logger = logger
+	}
+}
+
+// debugLogger - stderr logger used when CreateEdgex is called with debug > 0
+func debugLogger() Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+// operation - S3X API call description attached to every request it sends
+type operation struct {
+	name    string
+	bucket  string
+	object  string
+	key     string
+	session string
+	// number of keys updated by k/v request
+	keys int
+}
+
+// logArgs - structured fields describing operation
+func (op *operation) logArgs(args ...any) []any {
+	fields := []any{"operation", op.name}
+	if op.bucket != "" {
+		fields = append(fields, "bucket", op.bucket)
+	}
+	if op.object != "" {
+		fields = append(fields, "object", op.object)
+	}
+	if op.key != "" {
+		fields = append(fields, "key", op.key)
+	}
+	if op.session != "" {
+		fields = append(fields, "session_id", op.session)
+	}
+	return append(fields, args...)
+}
@@ -0,0 +1,33 @@
+package v1beta1
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LoggerStructuredFields(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client, err := CreateEdgex(srv.URL, "", "", 0, SetLogger(logger))
+	assert.Nil(t, err)
+
+	assert.NotNil(t, client.ObjectHead("bk", "obj"))
+	assert.Contains(t, out.String(), "operation=ObjectHead bucket=bk object=obj status=404 latency=")
+}
+
+func Test_LoggerSilentByDefault(t *testing.T) {
+	client, err := CreateEdgex("http://localhost:1", "", "", 0)
+	assert.Nil(t, err)
+	_, isNop := client.(*Edgex).logger.(nopLogger)
+	assert.True(t, isNop)
+}
@@ -0,0 +1,36 @@
+package v1beta1
+
+import (
+	"context"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+)
+
+// ObjectSetMetadata - replace all user metadata of object, empty meta removes it.
+// Object is copied onto itself with new metadata, so its data and content type are kept
+func (edgex *Edgex) ObjectSetMetadata(bucket, object string, meta map[string]string) error {
+	return edgex.ObjectSetMetadataCtx(context.Background(), bucket, object, meta)
+}
+
+// ObjectSetMetadataCtx - replace all user metadata of object within ctx
+func (edgex *Edgex) ObjectSetMetadataCtx(ctx context.Context, bucket, object string, meta map[string]string) error {
+	if meta == nil {
+		// nil metadata would be copied from the object itself
+		meta = map[string]string{}
+	}
+	return edgex.ObjectCopyCtx(ctx, bucket, object, bucket, object, s3xApi.CopyOptions{Metadata: meta})
+}
+
+// ObjectGetMetadata - user metadata of object with lower case keys, nil if object has none
+func (edgex *Edgex) ObjectGetMetadata(bucket, object string) (map[string]string, error) {
+	return edgex.ObjectGetMetadataCtx(context.Background(), bucket, object)
+}
+
+// ObjectGetMetadataCtx - user metadata of object within ctx
+func (edgex *Edgex) ObjectGetMetadataCtx(ctx context.Context, bucket, object string) (map[string]string, error) {
+	info, err := edgex.ObjectStatCtx(ctx, bucket, object)
+	if err != nil {
+		return nil, err
+	}
+	return info.Metadata, nil
+}
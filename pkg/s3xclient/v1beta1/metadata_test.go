@@ -0,0 +1,61 @@
+package v1beta1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ObjectMetadata(t *testing.T) {
+	// metadata headers stored by create and replaced by copy
+	meta := http.Header{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST", "PUT":
+			if r.Method == "PUT" {
+				assert.Equal(t, "/bk/img", r.Header.Get("x-amz-copy-source"))
+				assert.Equal(t, "REPLACE", r.Header.Get("x-amz-metadata-directive"))
+				assert.Equal(t, "image/png", r.Header.Get("Content-Type"))
+				w.Write([]byte("<CopyObjectResult><ETag>\"etag\"</ETag></CopyObjectResult>"))
+			}
+			meta = http.Header{}
+			for name, values := range r.Header {
+				if strings.HasPrefix(strings.ToLower(name), "x-amz-meta-") {
+					meta[name] = values
+				}
+			}
+		case "HEAD":
+			w.Header().Set("Content-Type", "image/png")
+			for name, values := range meta {
+				w.Header()[name] = values
+			}
+		}
+	}))
+	defer srv.Close()
+
+	client, err := CreateEdgex(srv.URL, "", "", 0)
+	assert.Nil(t, err)
+
+	err = client.ObjectCreateWithMetadata("bk", "img", s3xApi.OBJECT_TYPE_OBJECT, "image/png",
+		s3xApi.DEFAULT_CHUNKSIZE, s3xApi.DEFAULT_BTREE_ORDER, map[string]string{"Source": "lab", "camera": "cam-1"})
+	assert.Nil(t, err)
+	values, err := client.ObjectGetMetadata("bk", "img")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"source": "lab", "camera": "cam-1"}, values)
+
+	err = client.ObjectSetMetadata("bk", "img", map[string]string{"run": "42"})
+	assert.Nil(t, err)
+	values, err = client.ObjectGetMetadata("bk", "img")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"run": "42"}, values)
+
+	// nil metadata clears it instead of keeping the current one
+	assert.Nil(t, client.ObjectSetMetadata("bk", "img", nil))
+	values, err = client.ObjectGetMetadata("bk", "img")
+	assert.Nil(t, err)
+	assert.Nil(t, values)
+}
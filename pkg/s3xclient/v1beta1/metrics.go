@@ -0,0 +1,165 @@
+package v1beta1
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "s3x_client"
+
+// metrics - prometheus collectors of Edgex operations, nil if metrics are disabled
+type metrics struct {
+	requests      *prometheus.CounterVec
+	errors        *prometheus.CounterVec
+	latency       *prometheus.HistogramVec
+	bytesSent     *prometheus.CounterVec
+	bytesReceived *prometheus.CounterVec
+	retries       *prometheus.CounterVec
+	sessions      prometheus.Gauge
+}
+
+// SetMetrics - export operation metrics to registerer.
+// Several clients may share one registerer, their metrics are aggregated
+func SetMetrics(registerer prometheus.Registerer) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.metricsRegisterer = registerer
+	}
+}
+
+func newMetrics(registerer prometheus.Registerer) (*metrics, error) {
+	m := &metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "requests_total",
+			Help:      "S3X operations performed.",
+		}, []string{"operation"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "errors_total",
+			Help:      "S3X operations failed, by HTTP status code or 'transport' for connection failures.",
+		}, []string{"operation", "code"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "request_duration_seconds",
+			Help:      "S3X operation latency including retries.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+		}, []string{"operation"}),
+		bytesSent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "sent_bytes_total",
+			Help:      "Request payload bytes sent.",
+		}, []string{"operation"}),
+		bytesReceived: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "received_bytes_total",
+			Help:      "Response payload bytes received.",
+		}, []string{"operation"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "retries_total",
+			Help:      "S3X request attempts replayed after transient failures.",
+		}, []string{"operation"}),
+		sessions: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "kv_sessions_open",
+			Help:      "Key/value transaction sessions opened and not yet committed or rolled back.",
+		}),
+	}
+
+	var err error
+	m.requests, err = register(registerer, m.requests)
+	if err == nil {
+		m.errors, err = register(registerer, m.errors)
+	}
+	if err == nil {
+		m.latency, err = register(registerer, m.latency)
+	}
+	if err == nil {
+		m.bytesSent, err = register(registerer, m.bytesSent)
+	}
+	if err == nil {
+		m.bytesReceived, err = register(registerer, m.bytesReceived)
+	}
+	if err == nil {
+		m.retries, err = register(registerer, m.retries)
+	}
+	if err == nil {
+		m.sessions, err = register(registerer, m.sessions)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// register - register collector or reuse one already registered by another client
+func register[T prometheus.Collector](registerer prometheus.Registerer, collector T) (T, error) {
+	err := registerer.Register(collector)
+	var already prometheus.AlreadyRegisteredError
+	if errors.As(err, &already) {
+		if existing, ok := already.ExistingCollector.(T); ok {
+			return existing, nil
+		}
+	}
+	return collector, err
+}
+
+// observe - account completed operation
+func (m *metrics) observe(op *operation, req *http.Request, res *http.Response, err error, latency time.Duration) {
+	if m == nil {
+		return
+	}
+	m.requests.WithLabelValues(op.name).Inc()
+	m.latency.WithLabelValues(op.name).Observe(latency.Seconds())
+	if req.ContentLength > 0 {
+		m.bytesSent.WithLabelValues(op.name).Add(float64(req.ContentLength))
+	}
+	if err != nil {
+		m.errors.WithLabelValues(op.name, "transport").Inc()
+		return
+	}
+	if res.StatusCode >= 400 {
+		m.errors.WithLabelValues(op.name, strconv.Itoa(res.StatusCode)).Inc()
+	}
+
+	// k/v session is opened by first update and closed by commit or rollback
+	q := req.URL.Query()
+	_, finalize := q["finalize"]
+	_, cancel := q["cancel"]
+	if q.Get("comp") == "kv" {
+		if op.session != "" && (finalize || cancel) {
+			m.sessions.Dec()
+		} else if op.session == "" && !finalize && !cancel &&
+			q.Get("x-ccow-autocommit") == "0" && res.Header.Get("X-Session-Id") != "" {
+			m.sessions.Inc()
+		}
+	}
+
+	res.Body = &countingBody{ReadCloser: res.Body, counter: m.bytesReceived.WithLabelValues(op.name)}
+}
+
+func (m *metrics) retry(op *operation) {
+	if m == nil {
+		return
+	}
+	m.retries.WithLabelValues(op.name).Inc()
+}
+
+// countingBody - response body accounting bytes read by caller
+type countingBody struct {
+	io.ReadCloser
+	counter prometheus.Counter
+}
+
+func (body *countingBody) Read(p []byte) (int, error) {
+	n, err := body.ReadCloser.Read(p)
+	if n > 0 {
+		body.counter.Add(float64(n))
+	}
+	return n, err
+}
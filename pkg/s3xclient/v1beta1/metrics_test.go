@@ -0,0 +1,78 @@
+package v1beta1
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+// metricValue - sum of counter/gauge values of family name with matching labels
+func metricValue(t *testing.T, registry *prometheus.Registry, name string, labels map[string]string) float64 {
+	families, err := registry.Gather()
+	assert.Nil(t, err)
+
+	value := 0.0
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			if !hasLabels(m, labels) {
+				continue
+			}
+			value += m.GetCounter().GetValue() + m.GetGauge().GetValue() + float64(m.GetHistogram().GetSampleCount())
+		}
+	}
+	return value
+}
+
+func hasLabels(m *dto.Metric, labels map[string]string) bool {
+	found := 0
+	for _, pair := range m.GetLabel() {
+		if v, ok := labels[pair.GetName()]; ok && v == pair.GetValue() {
+			found++
+		}
+	}
+	return found == len(labels)
+}
+
+func Test_Metrics(t *testing.T) {
+	server := &sessionServer{keys: map[string]string{}, committed: map[string]bool{}}
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	registry := prometheus.NewRegistry()
+	client, err := CreateEdgex(srv.URL, "", "", 0, SetMetrics(registry))
+	assert.Nil(t, err)
+	// second client on same registry shares collectors
+	_, err = CreateEdgex(srv.URL, "", "", 0, SetMetrics(registry))
+	assert.Nil(t, err)
+
+	assert.Nil(t, client.BucketHead("bk"))
+
+	tx, err := client.BeginKV("bk", "obj")
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Put("k1", bytes.NewBufferString("value"), ""))
+	assert.Nil(t, tx.Put("k2", bytes.NewBufferString("value"), ""))
+	assert.Equal(t, 1.0, metricValue(t, registry, "s3x_client_kv_sessions_open", nil))
+	assert.Nil(t, tx.Commit())
+
+	assert.Equal(t, 1.0, metricValue(t, registry, "s3x_client_requests_total", map[string]string{"operation": "BucketHead"}))
+	assert.Equal(t, 2.0, metricValue(t, registry, "s3x_client_requests_total", map[string]string{"operation": "KVTx.Put"}))
+	assert.Equal(t, 10.0, metricValue(t, registry, "s3x_client_sent_bytes_total", map[string]string{"operation": "KVTx.Put"}))
+	assert.Equal(t, 1.0, metricValue(t, registry, "s3x_client_request_duration_seconds", map[string]string{"operation": "KVTx.Commit"}))
+	assert.Equal(t, 0.0, metricValue(t, registry, "s3x_client_kv_sessions_open", nil))
+
+	// failed operations are counted by status code
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	defer notFound.Close()
+	client, err = CreateEdgex(notFound.URL, "", "", 0, SetMetrics(registry))
+	assert.Nil(t, err)
+	assert.NotNil(t, client.ObjectHead("bk", "obj"))
+	assert.Equal(t, 1.0, metricValue(t, registry, "s3x_client_errors_total", map[string]string{"operation": "ObjectHead", "code": "404"}))
+}
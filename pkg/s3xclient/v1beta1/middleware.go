@@ -0,0 +1,57 @@
+package v1beta1
+
+import (
+	"net/http"
+)
+
+// RequestInfo - S3X operation the request belongs to
+type RequestInfo struct {
+	Operation string
+	Bucket    string
+	Object    string
+	Key       string
+	SessionID string
+	// Request url, already routed to the endpoint it is sent to
+	URL S3XURL
+}
+
+// RoundTripFunc - performs single HTTP attempt of S3X operation
+type RoundTripFunc func(req *http.Request, info *RequestInfo) (*http.Response, error)
+
+// Middleware - wraps HTTP attempts, i.e. to add headers, audit requests, rewrite urls or inject faults.
+// Requests are signed after all middlewares, so headers they add are covered by signature
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// WithMiddleware - add middlewares to the request chain.
+// The first middleware added is the outermost one and sees the request first
+func WithMiddleware(middlewares ...Middleware) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.middlewares = append(edgex.middlewares, middlewares...)
+	}
+}
+
+// buildRoundTrip - compose middlewares around signing http round trip
+func (edgex *Edgex) buildRoundTrip() RoundTripFunc {
+	roundTrip := func(req *http.Request, info *RequestInfo) (*http.Response, error) {
+		if err := edgex.signRequest(req); err != nil {
+			return nil, err
+		}
+		return edgex.httpClient.Do(req)
+	}
+	for i := len(edgex.middlewares) - 1; i >= 0; i-- {
+		roundTrip = edgex.middlewares[i](roundTrip)
+	}
+	return roundTrip
+}
+
+// requestInfo - operation description passed to middlewares
+func (op *operation) requestInfo(req *http.Request) *RequestInfo {
+	return &RequestInfo{
+		Operation: op.name,
+		Bucket:    op.bucket,
+		Object:    op.object,
+		Key:       op.key,
+		SessionID: req.Header.Get("x-session-id"),
+		URL:       S3XURL{URL: *req.URL},
+	}
+}
@@ -0,0 +1,57 @@
+package v1beta1
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Middleware(t *testing.T) {
+	var tenant string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant = r.Header.Get("X-Tenant")
+	}))
+	defer srv.Close()
+
+	var order []string
+	var seen *RequestInfo
+	trace := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request, info *RequestInfo) (*http.Response, error) {
+				order = append(order, name)
+				return next(req, info)
+			}
+		}
+	}
+	addTenant := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request, info *RequestInfo) (*http.Response, error) {
+			seen = info
+			req.Header.Set("X-Tenant", "acme")
+			return next(req, info)
+		}
+	}
+
+	client, err := CreateEdgex(srv.URL, "", "", 0, WithMiddleware(trace("outer"), trace("inner")), WithMiddleware(addTenant))
+	assert.Nil(t, err)
+	assert.Nil(t, client.ObjectHead("bk", "obj"))
+
+	assert.Equal(t, []string{"outer", "inner"}, order)
+	assert.Equal(t, "acme", tenant)
+	assert.Equal(t, "ObjectHead", seen.Operation)
+	assert.Equal(t, "bk", seen.Bucket)
+	assert.Equal(t, "obj", seen.Object)
+	assert.Equal(t, "/bk/obj", seen.URL.Path)
+
+	// faults injected by middleware are returned to caller
+	chaos := errors.New("chaos")
+	client, err = CreateEdgex(srv.URL, "", "", 0, WithMiddleware(func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request, info *RequestInfo) (*http.Response, error) {
+			return nil, chaos
+		}
+	}))
+	assert.Nil(t, err)
+	assert.True(t, errors.Is(client.BucketHead("bk"), chaos))
+}
@@ -0,0 +1,418 @@
+package v1beta1
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+	"github.com/highpeakdata/edgex-go-connector/pkg/utils"
+)
+
+// S3 limit of parts in one multipart upload
+const maxMultipartParts = 10000
+
+// MultipartInitiate - start S3 multipart upload of object, returns upload id
+func (edgex *Edgex) MultipartInitiate(bucket, object, contentType string, meta map[string]string) (string, error) {
+	return edgex.MultipartInitiateCtx(context.Background(), bucket, object, contentType, meta)
+}
+
+// MultipartInitiateCtx - start S3 multipart upload of object within ctx
+func (edgex *Edgex) MultipartInitiateCtx(ctx context.Context, bucket, object, contentType string, meta map[string]string) (string, error) {
+	objectPath, err := utils.GetObjectPath(bucket, object)
+	if err != nil {
+		return "", err
+	}
+
+	s3xurl := edgex.newS3xURL(objectPath)
+	s3xurl.AddOptions(S3XURLOptions{
+		"uploads": "",
+	})
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s3xurl.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	req.Header.Set("Content-Type", contentType)
+	setMetadata(req.Header, meta)
+
+	res, err := edgex.do(req, &operation{name: "MultipartInitiate", bucket: bucket, object: object})
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return "", s3xErrors.NewAPIError(res, "MultipartInitiate", bucket, object, "")
+	}
+	var result s3xApi.InitiateMultipartUploadResult
+	if err := xml.NewDecoder(res.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("MultipartInitiate %s/%s response error: %v", bucket, object, err)
+	}
+	return result.UploadID, nil
+}
+
+// MultipartUploadPart - upload part partNumber (1...10000) of size bytes read from r
+func (edgex *Edgex) MultipartUploadPart(bucket, object, uploadID string, partNumber int, r io.Reader, size int64) (s3xApi.Part, error) {
+	return edgex.MultipartUploadPartCtx(context.Background(), bucket, object, uploadID, partNumber, r, size)
+}
+
+// MultipartUploadPartCtx - upload part partNumber of size bytes read from r within ctx
+func (edgex *Edgex) MultipartUploadPartCtx(ctx context.Context, bucket, object, uploadID string, partNumber int, r io.Reader, size int64) (s3xApi.Part, error) {
+	objectPath, err := utils.GetObjectPath(bucket, object)
+	if err != nil {
+		return s3xApi.Part{}, err
+	}
+
+	s3xurl := edgex.newS3xURL(objectPath)
+	s3xurl.AddOptions(S3XURLOptions{
+		"partNumber": strconv.Itoa(partNumber),
+		"uploadId":   uploadID,
+	})
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", s3xurl.String(), r)
+	if err != nil {
+		return s3xApi.Part{}, err
+	}
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+		req.GetBody = nil
+	}
+
+	res, err := edgex.do(req, &operation{name: "MultipartUploadPart", bucket: bucket, object: object})
+	if err != nil {
+		return s3xApi.Part{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return s3xApi.Part{}, s3xErrors.NewAPIError(res, "MultipartUploadPart", bucket, object, "")
+	}
+	return s3xApi.Part{PartNumber: partNumber, ETag: res.Header.Get("ETag"), Size: size}, nil
+}
+
+// MultipartComplete - assemble uploaded parts into object
+func (edgex *Edgex) MultipartComplete(bucket, object, uploadID string, parts []s3xApi.Part) error {
+	return edgex.MultipartCompleteCtx(context.Background(), bucket, object, uploadID, parts)
+}
+
+// MultipartCompleteCtx - assemble uploaded parts into object within ctx
+func (edgex *Edgex) MultipartCompleteCtx(ctx context.Context, bucket, object, uploadID string, parts []s3xApi.Part) error {
+	objectPath, err := utils.GetObjectPath(bucket, object)
+	if err != nil {
+		return err
+	}
+
+	complete := s3xApi.CompleteMultipartUpload{Parts: make([]s3xApi.Part, len(parts))}
+	for i, part := range parts {
+		complete.Parts[i] = s3xApi.Part{PartNumber: part.PartNumber, ETag: part.ETag}
+	}
+	sort.Slice(complete.Parts, func(i, j int) bool {
+		return complete.Parts[i].PartNumber < complete.Parts[j].PartNumber
+	})
+	body, err := xml.Marshal(complete)
+	if err != nil {
+		return err
+	}
+
+	s3xurl := edgex.newS3xURL(objectPath)
+	s3xurl.AddOptions(S3XURLOptions{
+		"uploadId": uploadID,
+	})
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s3xurl.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/xml")
+
+	res, err := edgex.do(req, &operation{name: "MultipartComplete", bucket: bucket, object: object})
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// completion failure may be reported in 200 OK response body
+	return resultError(res, "MultipartComplete", bucket, object)
+}
+
+// resultError - APIError of failed response or of 200 OK response with S3 <Error> body
+func resultError(res *http.Response, op, bucket, object string) error {
+	if res.StatusCode >= 300 {
+		return s3xErrors.NewAPIError(res, op, bucket, object, "")
+	}
+	result, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	var root struct {
+		XMLName xml.Name
+	}
+	if xml.Unmarshal(result, &root) == nil && root.XMLName.Local == "Error" {
+		res.Body = ioutil.NopCloser(bytes.NewReader(result))
+		return s3xErrors.NewAPIError(res, op, bucket, object, "")
+	}
+	return nil
+}
+
+// MultipartAbort - discard multipart upload and its uploaded parts
+func (edgex *Edgex) MultipartAbort(bucket, object, uploadID string) error {
+	return edgex.MultipartAbortCtx(context.Background(), bucket, object, uploadID)
+}
+
+// MultipartAbortCtx - discard multipart upload and its uploaded parts within ctx
+func (edgex *Edgex) MultipartAbortCtx(ctx context.Context, bucket, object, uploadID string) error {
+	objectPath, err := utils.GetObjectPath(bucket, object)
+	if err != nil {
+		return err
+	}
+
+	s3xurl := edgex.newS3xURL(objectPath)
+	s3xurl.AddOptions(S3XURLOptions{
+		"uploadId": uploadID,
+	})
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", s3xurl.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := edgex.do(req, &operation{name: "MultipartAbort", bucket: bucket, object: object})
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 300 {
+		return nil
+	}
+	return s3xErrors.NewAPIError(res, "MultipartAbort", bucket, object, "")
+}
+
+// MultipartListParts - parts uploaded so far, all pages of the list
+func (edgex *Edgex) MultipartListParts(bucket, object, uploadID string) ([]s3xApi.Part, error) {
+	return edgex.MultipartListPartsCtx(context.Background(), bucket, object, uploadID)
+}
+
+// MultipartListPartsCtx - parts uploaded so far within ctx
+func (edgex *Edgex) MultipartListPartsCtx(ctx context.Context, bucket, object, uploadID string) ([]s3xApi.Part, error) {
+	objectPath, err := utils.GetObjectPath(bucket, object)
+	if err != nil {
+		return nil, err
+	}
+
+	var parts []s3xApi.Part
+	marker := 0
+	for {
+		s3xurl := edgex.newS3xURL(objectPath)
+		s3xurl.AddOptions(S3XURLOptions{
+			"uploadId": uploadID,
+		})
+		if marker > 0 {
+			s3xurl.AddOptions(S3XURLOptions{
+				"part-number-marker": strconv.Itoa(marker),
+			})
+		}
+
+		var list s3xApi.ListPartsResult
+		if err := edgex.getXML(ctx, s3xurl, &operation{name: "MultipartListParts", bucket: bucket, object: object}, &list); err != nil {
+			return parts, err
+		}
+		parts = append(parts, list.Parts...)
+		if !list.IsTruncated || list.NextPartNumberMarker <= marker {
+			return parts, nil
+		}
+		marker = list.NextPartNumberMarker
+	}
+}
+
+// MultipartListUploads - multipart uploads in progress of bucket objects starting with prefix
+func (edgex *Edgex) MultipartListUploads(bucket, prefix string) ([]s3xApi.MultipartUpload, error) {
+	return edgex.MultipartListUploadsCtx(context.Background(), bucket, prefix)
+}
+
+// MultipartListUploadsCtx - multipart uploads in progress within ctx
+func (edgex *Edgex) MultipartListUploadsCtx(ctx context.Context, bucket, prefix string) ([]s3xApi.MultipartUpload, error) {
+	bucketPath, err := utils.GetBucketPath(bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	var uploads []s3xApi.MultipartUpload
+	keyMarker, uploadIDMarker := "", ""
+	for {
+		s3xurl := edgex.newS3xURL(bucketPath)
+		s3xurl.AddOptions(S3XURLOptions{
+			"uploads": "",
+		})
+		if prefix != "" {
+			s3xurl.AddOptions(S3XURLOptions{
+				"prefix": prefix,
+			})
+		}
+		if keyMarker != "" {
+			s3xurl.AddOptions(S3XURLOptions{
+				"key-marker":       keyMarker,
+				"upload-id-marker": uploadIDMarker,
+			})
+		}
+
+		var list s3xApi.ListMultipartUploadsResult
+		if err := edgex.getXML(ctx, s3xurl, &operation{name: "MultipartListUploads", bucket: bucket}, &list); err != nil {
+			return uploads, err
+		}
+		uploads = append(uploads, list.Uploads...)
+		if !list.IsTruncated || list.NextKeyMarker == "" {
+			return uploads, nil
+		}
+		keyMarker, uploadIDMarker = list.NextKeyMarker, list.NextUploadIDMarker
+	}
+}
+
+// getXML - GET s3xurl and decode XML response into v
+func (edgex *Edgex) getXML(ctx context.Context, s3xurl S3XURL, op *operation, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", s3xurl.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := edgex.do(req, op)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return s3xErrors.NewAPIError(res, op.name, op.bucket, op.object, "")
+	}
+	if err := xml.NewDecoder(res.Body).Decode(v); err != nil {
+		return fmt.Errorf("%s response error: %v", op.name, err)
+	}
+	return nil
+}
+
+// ObjectUploadMultipart - upload r of unknown length with S3 multipart upload.
+// Parts of opts.PartSize are read sequentially and uploaded concurrently, failed parts are retried.
+// Upload is aborted on failure or cancellation. Returns uploaded size
+func (edgex *Edgex) ObjectUploadMultipart(bucket, object string, r io.Reader, opts s3xApi.TransferOptions) (int64, error) {
+	return edgex.ObjectUploadMultipartCtx(context.Background(), bucket, object, r, opts)
+}
+
+// ObjectUploadMultipartCtx - upload r of unknown length with S3 multipart upload within ctx
+func (edgex *Edgex) ObjectUploadMultipartCtx(ctx context.Context, bucket, object string, r io.Reader, opts s3xApi.TransferOptions) (int64, error) {
+	opts = transferOptions(opts, 0)
+	uploadID, err := edgex.MultipartInitiateCtx(ctx, bucket, object, opts.ContentType, opts.Metadata)
+	if err != nil {
+		return 0, err
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	op := &operation{name: "ObjectUploadMultipart", bucket: bucket, object: object}
+	progress := &transferProgress{total: -1, report: opts.Progress}
+
+	var lock sync.Mutex
+	var parts []s3xApi.Part
+	var errOnce sync.Once
+	var firstErr error
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	var size int64
+	slots := make(chan struct{}, opts.Concurrency)
+	var wg sync.WaitGroup
+read:
+	for number := 1; ; number++ {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			break read
+		}
+		if number > maxMultipartParts {
+			<-slots
+			fail(fmt.Errorf("%s/%s exceeds %d parts of %d bytes", bucket, object, maxMultipartParts, opts.PartSize))
+			break
+		}
+
+		buf := make([]byte, opts.PartSize)
+		n, err := io.ReadFull(r, buf)
+		last := err == io.EOF || err == io.ErrUnexpectedEOF
+		if err != nil && !last {
+			<-slots
+			fail(err)
+			break
+		}
+		// empty object still needs one part
+		if n == 0 && number > 1 {
+			<-slots
+			break
+		}
+		size += int64(n)
+
+		wg.Add(1)
+		go func(number int, data []byte) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			var part s3xApi.Part
+			err := edgex.retryPart(ctx, op, opts.PartRetries, func() error {
+				var err error
+				part, err = edgex.MultipartUploadPartCtx(ctx, bucket, object, uploadID, number,
+					bytes.NewReader(data), int64(len(data)))
+				return err
+			})
+			if err != nil {
+				fail(err)
+				return
+			}
+			lock.Lock()
+			parts = append(parts, part)
+			lock.Unlock()
+			progress.add(len(data))
+		}(number, buf[:n])
+
+		if last {
+			break
+		}
+	}
+	wg.Wait()
+
+	if firstErr == nil {
+		err = edgex.MultipartCompleteCtx(ctx, bucket, object, uploadID, parts)
+	} else {
+		err = firstErr
+	}
+	if err != nil {
+		// ctx may be cancelled already
+		edgex.MultipartAbortCtx(context.Background(), bucket, object, uploadID)
+		if parent.Err() != nil {
+			return 0, parent.Err()
+		}
+		return 0, err
+	}
+	return size, nil
+}
+
+// setMetadata - add user metadata headers
+func setMetadata(header http.Header, meta map[string]string) {
+	for key, value := range meta {
+		header.Set(headerMetaPrefix+key, value)
+	}
+}
@@ -0,0 +1,173 @@
+package v1beta1
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// multipartServer keeps multipart uploads in memory, lists parts by pages of 2
+type multipartServer struct {
+	lock    sync.Mutex
+	objects map[string][]byte
+	uploads map[string]map[int][]byte
+	next    int
+	aborted int
+	// part number failing with 500 once or always
+	flaky  int
+	broken int
+}
+
+func newMultipartServer() *multipartServer {
+	return &multipartServer{objects: map[string][]byte{}, uploads: map[string]map[int][]byte{}}
+}
+
+func (s *multipartServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	q := r.URL.Query()
+	uploadID := q.Get("uploadId")
+	parts := s.uploads[uploadID]
+	if uploadID != "" && parts == nil {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("<Error><Code>NoSuchUpload</Code></Error>"))
+		return
+	}
+	switch {
+	case r.Method == "POST" && q.Has("uploads"):
+		s.next++
+		uploadID = fmt.Sprintf("upload-%d", s.next)
+		s.uploads[uploadID] = map[int][]byte{}
+		xml.NewEncoder(w).Encode(s3xApi.InitiateMultipartUploadResult{UploadID: uploadID})
+	case r.Method == "PUT":
+		number, _ := strconv.Atoi(q.Get("partNumber"))
+		if number == s.broken || number == s.flaky {
+			s.flaky = 0
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		parts[number], _ = io.ReadAll(r.Body)
+		sum := md5.Sum(parts[number])
+		w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+	case r.Method == "POST":
+		var complete s3xApi.CompleteMultipartUpload
+		xml.NewDecoder(r.Body).Decode(&complete)
+		var data []byte
+		for _, part := range complete.Parts {
+			data = append(data, parts[part.PartNumber]...)
+		}
+		s.objects[r.URL.Path] = data
+		delete(s.uploads, uploadID)
+		w.Write([]byte("<CompleteMultipartUploadResult/>"))
+	case r.Method == "DELETE":
+		s.aborted++
+		delete(s.uploads, uploadID)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == "GET":
+		marker, _ := strconv.Atoi(q.Get("part-number-marker"))
+		var numbers []int
+		for number := range parts {
+			if number > marker {
+				numbers = append(numbers, number)
+			}
+		}
+		sort.Ints(numbers)
+		list := s3xApi.ListPartsResult{UploadID: uploadID}
+		for i, number := range numbers {
+			if i == 2 {
+				list.IsTruncated = true
+				list.NextPartNumberMarker = numbers[1]
+				break
+			}
+			list.Parts = append(list.Parts, s3xApi.Part{PartNumber: number, Size: int64(len(parts[number]))})
+		}
+		xml.NewEncoder(w).Encode(list)
+	}
+}
+
+func Test_ObjectUploadMultipart(t *testing.T) {
+	content := make([]byte, 10500)
+	rand.New(rand.NewSource(4)).Read(content)
+
+	server := newMultipartServer()
+	server.flaky = 3
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	client, err := CreateEdgex(srv.URL, "", "", 0)
+	assert.Nil(t, err)
+
+	// reader of unknown length
+	var lock sync.Mutex
+	var transferred int64
+	size, err := client.ObjectUploadMultipart("bk", "obj", io.MultiReader(bytes.NewReader(content)), s3xApi.TransferOptions{
+		PartSize:    1000,
+		Concurrency: 3,
+		Progress: func(n, total int64) {
+			lock.Lock()
+			defer lock.Unlock()
+			assert.Equal(t, int64(-1), total)
+			transferred = n
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(len(content)), size)
+	assert.Equal(t, int64(len(content)), transferred)
+	assert.Equal(t, content, server.objects["/bk/obj"])
+	assert.Empty(t, server.uploads)
+
+	// failed part aborts upload
+	server.broken = 4
+	_, err = client.ObjectUploadMultipart("bk", "obj2", bytes.NewReader(content), s3xApi.TransferOptions{
+		PartSize:    1000,
+		PartRetries: s3xApi.NoPartRetries,
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, server.aborted)
+	assert.Empty(t, server.uploads)
+	assert.Nil(t, server.objects["/bk/obj2"])
+}
+
+func Test_MultipartListParts(t *testing.T) {
+	server := newMultipartServer()
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	client, err := CreateEdgex(srv.URL, "", "", 0)
+	assert.Nil(t, err)
+
+	uploadID, err := client.MultipartInitiate("bk", "obj", "", nil)
+	assert.Nil(t, err)
+	for number := 1; number <= 5; number++ {
+		part, err := client.MultipartUploadPart("bk", "obj", uploadID, number, bytes.NewReader([]byte("part")), 4)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, part.ETag)
+	}
+
+	parts, err := client.MultipartListParts("bk", "obj", uploadID)
+	assert.Nil(t, err)
+	assert.Len(t, parts, 5)
+	for i, part := range parts {
+		assert.Equal(t, i+1, part.PartNumber)
+	}
+
+	assert.Nil(t, client.MultipartAbort("bk", "obj", uploadID))
+	err = client.MultipartAbort("bk", "obj", uploadID)
+	assert.True(t, errors.Is(err, s3xErrors.ErrUploadNotExist), "unexpected error: %v", err)
+}
@@ -2,12 +2,14 @@ package v1beta1
 
 import (
 	"bytes"
+	"context"
 	"encoding/xml"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"strconv"
+	"sync"
 
 	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
 	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
@@ -17,148 +19,436 @@ import (
 
 type s3xObjectStream struct {
 	edgex  *Edgex
+	ctx    context.Context
+	bucket string
+	object string
 	path   string
 	offset int
+	// open mode, SS_RANDWR, SS_APPEND or SS_RDONLY
+	mode int
+
+	// size, dirty state and server stream session of stream writes, kept until Close or Abort
+	lock   sync.Mutex
 	size   int
 	dirty  bool
+	sid    string
+	closed bool
+	// logical size last reported or acknowledged by the server, short reads below it are holes
+	reported int
+	// serializes writes until first of them opens stream session
+	opening sync.Mutex
+	// guards writeBehind buffer
+	bufLock sync.Mutex
+
+	// read-ahead windows cache, nil if disabled
+	readAhead *readAhead
+	// coalesced pending writes, nil if disabled
+	writeBehind *writeBehind
 }
 
 func (s *s3xObjectStream) Read(p []byte) (n int, err error) {
+	if err := s.checkOpen(); err != nil {
+		return 0, err
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if err := s.Flush(); err != nil {
+		return 0, err
+	}
+	// reads stop at logical size, even if offset was moved past it
+	size := s.logicalSize()
+	if s.offset >= size {
+		return 0, io.EOF
+	}
+	if s.readAhead != nil {
+		n, err = s.readAhead.read(s, p, s.offset)
+		if err != nil {
+			return 0, err
+		}
+		if n == 0 {
+			return 0, io.EOF
+		}
+		s.offset += n
+		return n, nil
+	}
+
+	if s.offset+len(p) > size {
+		p = p[:size-s.offset]
+	}
+	n, err = s.readAt(s.ctx, p, s.offset)
+	if err == nil {
+		s.offset += n
+	}
+	return n, err
+}
+
+// readAt - read len(p) bytes at offset with single request.
+// Short body is a hole read as zeros only within logical size reported by the server,
+// otherwise it fails with io.ErrUnexpectedEOF
+func (s *s3xObjectStream) readAt(ctx context.Context, p []byte, offset int) (int, error) {
 	contentLen := len(p)
 	if contentLen == 0 {
 		return 0, nil
 	}
 	s3xurl := s.edgex.newS3xURL(s.path)
-	s3xurl.AddOptions(S3XURLOptions{
-		"comp":   "streamsession",
-		"cancel": "",
-	})
-	req, err := http.NewRequest("GET", s3xurl.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", s3xurl.String(), nil)
 	if err != nil {
 		return 0, fmt.Errorf("StreamRead create GET error: %v", err)
 	}
+	s.bindSession(req)
 
-	req.Header.Add("x-ccow-offset", strconv.Itoa(s.offset))
+	req.Header.Add("x-ccow-offset", strconv.Itoa(offset))
 	req.Header.Add("x-ccow-length", strconv.Itoa(contentLen))
 
-	res, err := s.edgex.httpClient.Do(req)
+	res, err := s.edgex.do(req, &operation{name: "StreamRead", bucket: s.bucket, object: s.object, session: s.sessionID()})
 	if err != nil {
-		return 0, fmt.Errorf("StreamRead GET error: %v", err)
+		return 0, fmt.Errorf("StreamRead GET error: %w", err)
 	}
 	defer res.Body.Close()
 	if res.StatusCode >= 300 {
-		return 0, fmt.Errorf("StreamRead GET Status code %v", res.StatusCode)
-	}
-	rdLen := 0
-	for {
-		i, err := res.Body.Read(p[rdLen:])
-		rdLen += i
-		if err != nil || rdLen >= cap(p) {
-			if err == io.EOF {
-				err = nil
-			}
-			break
+		return 0, s3xErrors.NewAPIError(res, "StreamRead", s.bucket, s.object, "")
+	}
+	rdLen, err := io.ReadFull(res.Body, p)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		if offset+len(p) > s.reportedSize() {
+			return rdLen, fmt.Errorf("StreamRead at %d: %d of %d bytes read: %w", offset, rdLen, len(p), io.ErrUnexpectedEOF)
+		}
+		for i := rdLen; i < len(p); i++ {
+			p[i] = 0
 		}
+		return len(p), nil
 	}
-	if err == nil {
-		s.offset += rdLen
+	if err != nil {
+		return rdLen, fmt.Errorf("StreamRead body error: %w", err)
 	}
-	return rdLen, err
+	return rdLen, nil
 }
 
 func (s *s3xObjectStream) Write(p []byte) (n int, err error) {
+	if err := s.checkOpen(); err != nil {
+		return 0, err
+	}
+	if s.mode == s3xApi.SS_RDONLY {
+		return 0, s.modeError("Write")
+	}
 	size := len(p)
 	if size == 0 {
 		return 0, nil
 	}
+	if s.mode == s3xApi.SS_APPEND {
+		s.offset = s.logicalSize()
+	}
+	if s.writeBehind != nil {
+		s.bufLock.Lock()
+		err = s.writeBehind.write(s, p, s.offset)
+		s.bufLock.Unlock()
+	} else {
+		err = s.writeAt(p, s.offset)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	s.grow(s.offset + size)
+	s.offset += size
+	return size, nil
+}
+
+// ReadAt - read len(p) bytes at off with single request, stream offset is not changed
+func (s *s3xObjectStream) ReadAt(p []byte, off int64) (int, error) {
+	if err := s.checkOpen(); err != nil {
+		return 0, err
+	}
+	if off < 0 {
+		return 0, fmt.Errorf("Invalid offset %v", off)
+	}
+	if err := s.Flush(); err != nil {
+		return 0, err
+	}
+	size := int64(s.logicalSize())
+	if off >= size {
+		return 0, io.EOF
+	}
+	buf := p
+	if off+int64(len(p)) > size {
+		buf = p[:size-off]
+	}
+	n, err := s.readAt(s.ctx, buf, int(off))
+	if err == nil && n < len(p) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// WriteAt - write p at off with single request, stream offset is not changed
+func (s *s3xObjectStream) WriteAt(p []byte, off int64) (int, error) {
+	if err := s.checkOpen(); err != nil {
+		return 0, err
+	}
+	if s.mode != s3xApi.SS_RANDWR {
+		return 0, s.modeError("WriteAt")
+	}
+	if off < 0 {
+		return 0, fmt.Errorf("Invalid offset %v", off)
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if err := s.Flush(); err != nil {
+		return 0, err
+	}
+	if err := s.writeAt(p, int(off)); err != nil {
+		return 0, err
+	}
+	s.grow(int(off) + len(p))
+	return len(p), nil
+}
+
+// Size - object logical size, including writes of this stream
+func (s *s3xObjectStream) Size() int64 {
+	return int64(s.logicalSize())
+}
+
+// Stat - refresh object logical size from the server.
+// Writes of this stream not finalized yet are accounted by its stream session
+func (s *s3xObjectStream) Stat() (int64, error) {
+	if err := s.checkOpen(); err != nil {
+		return 0, err
+	}
+	if err := s.Flush(); err != nil {
+		return 0, err
+	}
+	size, _, err := s.edgex.streamHead(s.ctx, "StreamStat", s.bucket, s.object, s.path, s.sessionID())
+	if err != nil {
+		return 0, err
+	}
+	s.lock.Lock()
+	s.size = size
+	s.reported = size
+	s.lock.Unlock()
+	return int64(size), nil
+}
+
+func (s *s3xObjectStream) logicalSize() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.size
+}
+
+func (s *s3xObjectStream) reportedSize() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.reported
+}
+
+// streamMode - stream open mode selected by flags
+func streamMode(flags []int) (int, error) {
+	mode := 0
+	for _, flag := range flags {
+		mode |= flag
+	}
+	switch mode {
+	case s3xApi.SS_CONT, s3xApi.SS_RANDWR:
+		return s3xApi.SS_RANDWR, nil
+	case s3xApi.SS_APPEND, s3xApi.SS_RDONLY:
+		return mode, nil
+	}
+	return 0, fmt.Errorf("Invalid stream open flags %#x", mode)
+}
+
+// modeError - op is not permitted in stream open mode
+func (s *s3xObjectStream) modeError(op string) error {
+	return fmt.Errorf("%s %s/%s: %w", op, s.bucket, s.object, s3xErrors.ErrStreamMode)
+}
+
+// grow - account write ending at end
+func (s *s3xObjectStream) grow(end int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if end > s.size {
+		s.size = end
+	}
+	s.dirty = true
+}
+
+// writeAt - write p at offset with single request
+func (s *s3xObjectStream) writeAt(p []byte, offset int) error {
+	return s.post("StreamWrite", p, offset, -1)
+}
+
+// post - send stream session update writing p at offset and, unless logicalSize is negative,
+// setting object logical size
+func (s *s3xObjectStream) post(op string, p []byte, offset int, logicalSize int) error {
+	// concurrent writes have to join the same session
+	s.opening.Lock()
+	if s.sessionID() != "" {
+		s.opening.Unlock()
+	} else {
+		defer s.opening.Unlock()
+	}
+
+	size := len(p)
 	s3xurl := s.edgex.newS3xURL(s.path)
-	s3xurl.AddOptions(S3XURLOptions{
-		"comp":     "streamsession",
-		"finalize": "",
-	})
-	req, err := http.NewRequest("POST", s3xurl.String(), bytes.NewBuffer(p))
+	req, err := http.NewRequestWithContext(s.ctx, "POST", s3xurl.String(), bytes.NewBuffer(p))
 	if err != nil {
-		return 0, fmt.Errorf("StreamWrite create POST error: %v", err)
+		return fmt.Errorf("%s create POST error: %v", op, err)
 	}
+	s.bindSession(req)
 
-	req.Header.Add("x-ccow-offset", strconv.Itoa(int(s.offset)))
-	req.Header.Add("x-ccow-length", strconv.Itoa(int(size)))
+	req.Header.Add("x-ccow-offset", strconv.Itoa(offset))
+	req.Header.Add("x-ccow-length", strconv.Itoa(size))
+	if logicalSize >= 0 {
+		req.Header.Add(headerLogicalSize, strconv.Itoa(logicalSize))
+	}
 
-	res, err := s.edgex.httpClient.Do(req)
+	res, err := s.edgex.do(req, &operation{name: op, bucket: s.bucket, object: s.object, session: s.sessionID()})
 	if err != nil {
-		return 0, fmt.Errorf("StreamWrite POST error: %v", err)
+		return fmt.Errorf("%s POST error: %w", op, err)
 	}
 	defer res.Body.Close()
 	if res.StatusCode >= 300 {
-		return 0, fmt.Errorf("StreamWrite POST Status code %v", res.StatusCode)
+		return s3xErrors.NewAPIError(res, op, s.bucket, s.object, "")
+	}
+	if sid := res.Header.Get("X-Session-Id"); sid != "" {
+		s.setSessionID(sid)
 	}
 
-	if s.offset+size > s.size {
-		s.size = s.offset + size
+	if s.readAhead != nil {
+		s.readAhead.invalidateRange(offset, size)
 	}
-	s.offset += size
-	s.dirty = true
-	return size, nil
+	return nil
+}
+
+// Flush - send writes buffered in write-behind mode
+func (s *s3xObjectStream) Flush() error {
+	if s.writeBehind == nil {
+		return nil
+	}
+	s.bufLock.Lock()
+	defer s.bufLock.Unlock()
+	return s.writeBehind.flush(s)
 }
 
 func (s *s3xObjectStream) Seek(offset int64, whence int) (int64, error) {
+	if err := s.checkOpen(); err != nil {
+		return 0, err
+	}
+	if err := s.Flush(); err != nil {
+		return 0, err
+	}
 	newPos := 0
 	if whence == io.SeekCurrent {
 		newPos = s.offset + int(offset)
 	} else if whence == io.SeekEnd {
-		newPos = s.size + int(offset)
+		newPos = s.logicalSize() + int(offset)
 	} else if whence == io.SeekStart {
 		newPos = int(offset)
 	}
+	// moving past the end is allowed, next write leaves a hole read as zeros
 	if newPos < 0 {
 		return 0, fmt.Errorf("Invalid offset %v", offset)
 	}
 	s.offset = newPos
+	if s.readAhead != nil {
+		// cancel prefetch of windows not needed at new position
+		s.readAhead.retain(newPos / s.readAhead.window * s.readAhead.window)
+	}
 	return int64(newPos), nil
 }
 
-func (s *s3xObjectStream) Close() error {
-	return nil
+// ObjectGetStream - open object stream for read/write operations.
+// flags select stream mode: SS_RANDWR (default) reads and writes anywhere,
+// SS_APPEND writes always at the end of object and SS_RDONLY rejects writes
+func (edgex *Edgex) ObjectGetStream(bucket, object string, flags ...int) (s3xApi.ObjectStream, error) {
+	return edgex.ObjectGetStreamCtx(context.Background(), bucket, object, flags...)
 }
 
-func (edgex *Edgex) ObjectGetStream(bucket, object string) (s3xApi.ObjectStream, error) {
+// ObjectGetStreamCtx - open object stream bound to ctx.
+// ctx is used by every stream Read/Write request, so cancelling it aborts stream IO.
+func (edgex *Edgex) ObjectGetStreamCtx(ctx context.Context, bucket, object string, flags ...int) (s3xApi.ObjectStream, error) {
 	objectPath, err := utils.GetObjectPath(bucket, object)
 	if err != nil {
 		return nil, err
 	}
+	mode, err := streamMode(flags)
+	if err != nil {
+		return nil, err
+	}
 
+	size, chunkSize, err := edgex.streamHead(ctx, "ObjectGetStream", bucket, object, objectPath, "")
+	if err != nil {
+		return nil, err
+	}
+	stream := &s3xObjectStream{
+		edgex:    edgex,
+		ctx:      ctx,
+		bucket:   bucket,
+		object:   object,
+		path:     objectPath,
+		size:     size,
+		reported: size,
+		mode:     mode,
+	}
+	if edgex.readAheadWindow > 0 {
+		stream.readAhead = newReadAhead(edgex.readAheadWindow, edgex.readAheadPrefetch, chunkSize)
+	}
+	if edgex.writeBehindSize > 0 {
+		stream.writeBehind = newWriteBehind(edgex.writeBehindSize, chunkSize)
+	}
+	return stream, nil
+}
+
+// streamHead - stream object logical size and chunk size, as seen by stream session sid if not empty
+func (edgex *Edgex) streamHead(ctx context.Context, op, bucket, object, objectPath, sid string) (int, int, error) {
 	s3xurl := edgex.newS3xURL(objectPath)
 	s3xurl.AddOptions(S3XURLOptions{
-		"comp":   "streamsession",
-		"cancel": "",
+		"comp": "streamsession",
 	})
+	if sid == "" {
+		s3xurl.AddOptions(S3XURLOptions{
+			"cancel": "",
+		})
+	}
 
-	res, err := http.Head(s3xurl.String())
+	req, err := http.NewRequestWithContext(ctx, "HEAD", s3xurl.String(), nil)
 	if err != nil {
-		fmt.Printf("Object Head error: %v\n", err)
-		return nil, err
+		return 0, 0, err
+	}
+	if sid != "" {
+		req.Header.Add("x-session-id", sid)
 	}
 
-	if res.StatusCode == 404 {
-		return nil, s3xErrors.ErrObjectNotExist
+	res, err := edgex.do(req, &operation{name: op, bucket: bucket, object: object, session: sid})
+	if err != nil {
+		return 0, 0, err
 	}
+	defer res.Body.Close()
+
 	if res.StatusCode >= 300 {
-		return nil, fmt.Errorf("Object %s/ head error: %v", objectPath, err)
-	}
-	sizeStr := res.Header.Get("x-ccow-logical-size")
-	size := 0
-	if len(sizeStr) > 0 {
-		size, _ = strconv.Atoi(sizeStr)
-	}
-	return &s3xObjectStream{
-		edgex: edgex,
-		path:  objectPath,
-		size:  size,
-	}, nil
+		return 0, 0, s3xErrors.NewAPIError(res, op, bucket, object, "")
+	}
+	info := objectInfo(bucket, object, res.Header)
+	return int(info.Size), info.ChunkSize, nil
 }
 
 // ObjectCreate - create key/value object
 func (edgex *Edgex) ObjectCreate(bucket, object string, objectType s3xApi.ObjectType, contentType string, chunkSize int, btreeOrder int) error {
+	return edgex.ObjectCreateCtx(context.Background(), bucket, object, objectType, contentType, chunkSize, btreeOrder)
+}
+
+// ObjectCreateCtx - create key/value object within ctx
+func (edgex *Edgex) ObjectCreateCtx(ctx context.Context, bucket, object string, objectType s3xApi.ObjectType, contentType string, chunkSize int, btreeOrder int) error {
+	return edgex.ObjectCreateWithMetadataCtx(ctx, bucket, object, objectType, contentType, chunkSize, btreeOrder, nil)
+}
+
+// ObjectCreateWithMetadata - create object with user metadata attached
+func (edgex *Edgex) ObjectCreateWithMetadata(bucket, object string, objectType s3xApi.ObjectType, contentType string, chunkSize int, btreeOrder int, meta map[string]string) error {
+	return edgex.ObjectCreateWithMetadataCtx(context.Background(), bucket, object, objectType, contentType, chunkSize, btreeOrder, meta)
+}
+
+// ObjectCreateWithMetadataCtx - create object with user metadata attached within ctx
+func (edgex *Edgex) ObjectCreateWithMetadataCtx(ctx context.Context, bucket, object string, objectType s3xApi.ObjectType, contentType string, chunkSize int, btreeOrder int, meta map[string]string) error {
 
 	objectPath, err := utils.GetObjectPath(bucket, object)
 	if err != nil {
@@ -179,9 +469,8 @@ func (edgex *Edgex) ObjectCreate(bucket, object string, objectType s3xApi.Object
 
 	}
 
-	req, err := http.NewRequest("POST", s3xurl.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "POST", s3xurl.String(), nil)
 	if err != nil {
-		fmt.Printf("k/v create post error: %v\n", err)
 		return err
 	}
 
@@ -190,21 +479,26 @@ func (edgex *Edgex) ObjectCreate(bucket, object string, objectType s3xApi.Object
 	req.Header.Add("x-ccow-object-oflags", strconv.Itoa(s3xApi.CCOW_O_CREATE|s3xApi.CCOW_O_REPLACE))
 	req.Header.Add("x-ccow-chunkmap-btree-order", strconv.Itoa(btreeOrder))
 	req.Header.Add("x-ccow-chunkmap-chunk-size", strconv.Itoa(chunkSize))
+	setMetadata(req.Header, meta)
 
-	res, err := edgex.httpClient.Do(req)
+	res, err := edgex.do(req, &operation{name: "ObjectCreate", bucket: bucket, object: object})
 	if err != nil {
-		fmt.Printf("k/v create error: %v\n", err)
 		return err
 	}
 	defer res.Body.Close()
 	if res.StatusCode < 300 {
 		return nil
 	}
-	return fmt.Errorf("%s create status code: %v", objectPath, res.StatusCode)
+	return s3xErrors.NewAPIError(res, "ObjectCreate", bucket, object, "")
 }
 
 // ObjectDelete - delete object
 func (edgex *Edgex) ObjectDelete(bucket, object string) error {
+	return edgex.ObjectDeleteCtx(context.Background(), bucket, object)
+}
+
+// ObjectDeleteCtx - delete object within ctx
+func (edgex *Edgex) ObjectDeleteCtx(ctx context.Context, bucket, object string) error {
 
 	objectPath, err := utils.GetObjectPath(bucket, object)
 	if err != nil {
@@ -216,25 +510,29 @@ func (edgex *Edgex) ObjectDelete(bucket, object string) error {
 		"comp": "del",
 	})
 
-	req, err := http.NewRequest("DELETE", s3xurl.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", s3xurl.String(), nil)
 	if err != nil {
-		fmt.Printf("k/v create object delete error: %v\n", err)
 		return err
 	}
 
-	res, err := edgex.httpClient.Do(req)
+	res, err := edgex.do(req, &operation{name: "ObjectDelete", bucket: bucket, object: object})
 	if err != nil {
-		fmt.Printf("k/v object delete error: %v\n", err)
 		return err
 	}
+	defer res.Body.Close()
 	if res.StatusCode < 300 {
 		return nil
 	}
-	return fmt.Errorf("%s object delete status code: %v", objectPath, res.StatusCode)
+	return s3xErrors.NewAPIError(res, "ObjectDelete", bucket, object, "")
 }
 
 // ObjectHead - read object header fields
 func (edgex *Edgex) ObjectHead(bucket, object string) error {
+	return edgex.ObjectHeadCtx(context.Background(), bucket, object)
+}
+
+// ObjectHeadCtx - read object header fields within ctx
+func (edgex *Edgex) ObjectHeadCtx(ctx context.Context, bucket, object string) error {
 	objectPath, err := utils.GetObjectPath(bucket, object)
 	if err != nil {
 		return err
@@ -246,22 +544,30 @@ func (edgex *Edgex) ObjectHead(bucket, object string) error {
 		"finalize": "",
 	})
 
-	res, err := http.Head(s3xurl.String())
+	req, err := http.NewRequestWithContext(ctx, "HEAD", s3xurl.String(), nil)
 	if err != nil {
-		fmt.Printf("Object Head error: %v\n", err)
 		return err
 	}
 
+	res, err := edgex.do(req, &operation{name: "ObjectHead", bucket: bucket, object: object})
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
 	if res.StatusCode < 300 {
 		return nil
 	}
-	if res.StatusCode == 404 {
-		return s3xErrors.ErrObjectNotExist
-	}
-	return fmt.Errorf("Object %s/ head error: %v", objectPath, err)
+	return s3xErrors.NewAPIError(res, "ObjectHead", bucket, object, "")
 }
 
+// ObjectList - list bucket objects
 func (edgex *Edgex) ObjectList(bucket, from, pattern string, maxcount int) ([]s3xApi.Object, error) {
+	return edgex.ObjectListCtx(context.Background(), bucket, from, pattern, maxcount)
+}
+
+// ObjectListCtx - list bucket objects within ctx
+func (edgex *Edgex) ObjectListCtx(ctx context.Context, bucket, from, pattern string, maxcount int) ([]s3xApi.Object, error) {
 	bucketPath, err := utils.GetBucketPath(bucket)
 	if err != nil {
 		return nil, err
@@ -289,35 +595,25 @@ func (edgex *Edgex) ObjectList(bucket, from, pattern string, maxcount int) ([]s3
 
 	}
 
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", s3xurl.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", s3xurl.String(), nil)
 	if err != nil {
-		fmt.Printf("Object list error: %v\n", err)
 		return list.Objects, err
 	}
 
-	if edgex.Debug > 0 {
-		fmt.Printf("ObjectList request: %+v", req)
-	}
 	req.Header.Add("Content-Length", "0")
-	res, err := client.Do(req)
+	res, err := edgex.do(req, &operation{name: "ObjectList", bucket: bucket})
 	if err != nil {
-		fmt.Printf("Object list error: %v\n", err)
 		return list.Objects, err
 	}
 	defer res.Body.Close()
 
-	if edgex.Debug > 0 {
-		fmt.Printf("ObjectList response: %+v", res)
-	}
 	if res.StatusCode < 300 {
 		body, err := ioutil.ReadAll(res.Body)
 		if err != nil {
-			fmt.Printf("Object list read error: %v\n", err)
 			return list.Objects, err
 		}
 		err = xml.Unmarshal(body, &list)
 		return list.Objects, err
 	}
-	return list.Objects, fmt.Errorf("Bucket %s list error: %v", bucket, res)
+	return list.Objects, s3xErrors.NewAPIError(res, "ObjectList", bucket, "", "")
 }
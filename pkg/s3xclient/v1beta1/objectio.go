@@ -0,0 +1,132 @@
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+	"github.com/highpeakdata/edgex-go-connector/pkg/utils"
+)
+
+// ObjectPut - store whole object of size bytes read from r with single S3 PUT request.
+// size -1 means unknown size, the body is sent chunked then
+func (edgex *Edgex) ObjectPut(bucket, object string, r io.Reader, size int64, contentType string, meta map[string]string) error {
+	return edgex.ObjectPutCtx(context.Background(), bucket, object, r, size, contentType, meta)
+}
+
+// ObjectPutCtx - store whole object read from r within ctx
+func (edgex *Edgex) ObjectPutCtx(ctx context.Context, bucket, object string, r io.Reader, size int64, contentType string, meta map[string]string) error {
+	objectPath, err := utils.GetObjectPath(bucket, object)
+	if err != nil {
+		return err
+	}
+
+	s3xurl := edgex.newS3xURL(objectPath)
+	req, err := http.NewRequestWithContext(ctx, "PUT", s3xurl.String(), r)
+	if err != nil {
+		return err
+	}
+	if size >= 0 {
+		req.ContentLength = size
+		if size == 0 {
+			req.Body = http.NoBody
+			req.GetBody = nil
+		}
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	req.Header.Set("Content-Type", contentType)
+	setMetadata(req.Header, meta)
+
+	res, err := edgex.do(req, &operation{name: "ObjectPut", bucket: bucket, object: object})
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 300 {
+		return nil
+	}
+	return s3xErrors.NewAPIError(res, "ObjectPut", bucket, object, "")
+}
+
+// ObjectGet - read whole object with single S3 GET request.
+// Caller has to close returned reader
+func (edgex *Edgex) ObjectGet(bucket, object string) (io.ReadCloser, s3xApi.ObjectInfo, error) {
+	return edgex.ObjectGetRangeCtx(context.Background(), bucket, object, 0, -1)
+}
+
+// ObjectGetCtx - read whole object within ctx
+func (edgex *Edgex) ObjectGetCtx(ctx context.Context, bucket, object string) (io.ReadCloser, s3xApi.ObjectInfo, error) {
+	return edgex.ObjectGetRangeCtx(ctx, bucket, object, 0, -1)
+}
+
+// ObjectGetRange - read length bytes of object at offset with HTTP Range GET,
+// length -1 reads up to the end. Returned info carries size of the whole object
+func (edgex *Edgex) ObjectGetRange(bucket, object string, offset, length int64) (io.ReadCloser, s3xApi.ObjectInfo, error) {
+	return edgex.ObjectGetRangeCtx(context.Background(), bucket, object, offset, length)
+}
+
+// ObjectGetRangeCtx - read length bytes of object at offset within ctx
+func (edgex *Edgex) ObjectGetRangeCtx(ctx context.Context, bucket, object string, offset, length int64) (io.ReadCloser, s3xApi.ObjectInfo, error) {
+	objectPath, err := utils.GetObjectPath(bucket, object)
+	if err != nil {
+		return nil, s3xApi.ObjectInfo{}, err
+	}
+	if offset < 0 || length == 0 || length < -1 {
+		return nil, s3xApi.ObjectInfo{}, fmt.Errorf("Invalid range %v+%v", offset, length)
+	}
+
+	s3xurl := edgex.newS3xURL(objectPath)
+	req, err := http.NewRequestWithContext(ctx, "GET", s3xurl.String(), nil)
+	if err != nil {
+		return nil, s3xApi.ObjectInfo{}, err
+	}
+	ranged := offset > 0 || length > 0
+	if ranged {
+		byteRange := "bytes=" + strconv.FormatInt(offset, 10) + "-"
+		if length > 0 {
+			byteRange += strconv.FormatInt(offset+length-1, 10)
+		}
+		req.Header.Set("Range", byteRange)
+	}
+
+	res, err := edgex.do(req, &operation{name: "ObjectGet", bucket: bucket, object: object})
+	if err != nil {
+		return nil, s3xApi.ObjectInfo{}, err
+	}
+	if res.StatusCode >= 300 {
+		defer res.Body.Close()
+		return nil, s3xApi.ObjectInfo{}, s3xErrors.NewAPIError(res, "ObjectGet", bucket, object, "")
+	}
+
+	info := objectInfo(bucket, object, res.Header)
+	if !ranged || res.StatusCode == http.StatusPartialContent {
+		return res.Body, info, nil
+	}
+
+	// server ignored Range header and sent whole object
+	if _, err := io.CopyN(io.Discard, res.Body, offset); err != nil {
+		res.Body.Close()
+		if err == io.EOF {
+			err = fmt.Errorf("%s/%s range %v+%v: %w", bucket, object, offset, length, io.ErrUnexpectedEOF)
+		}
+		return nil, s3xApi.ObjectInfo{}, err
+	}
+	body := res.Body
+	if length > 0 {
+		body = &limitedBody{Reader: io.LimitReader(res.Body, length), Closer: res.Body}
+	}
+	return body, info, nil
+}
+
+// limitedBody - response body cut to requested range
+type limitedBody struct {
+	io.Reader
+	io.Closer
+}
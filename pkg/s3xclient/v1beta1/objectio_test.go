@@ -0,0 +1,82 @@
+package v1beta1
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ObjectPutGet(t *testing.T) {
+	objects := map[string][]byte{}
+	headers := map[string]http.Header{}
+	ignoreRange := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.URL.Query().Get("comp"))
+		switch r.Method {
+		case "PUT":
+			objects[r.URL.Path], _ = io.ReadAll(r.Body)
+			headers[r.URL.Path] = r.Header.Clone()
+		case "GET":
+			data, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", headers[r.URL.Path].Get("Content-Type"))
+			w.Header().Set("x-amz-meta-camera", headers[r.URL.Path].Get("x-amz-meta-camera"))
+			if ignoreRange {
+				r.Header.Del("Range")
+			}
+			http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+		}
+	}))
+	defer srv.Close()
+
+	client, err := CreateEdgex(srv.URL, "", "", 0)
+	assert.Nil(t, err)
+
+	content := "0123456789abcdefghij"
+	err = client.ObjectPut("bk", "img", strings.NewReader(content), int64(len(content)), "image/png",
+		map[string]string{"camera": "cam-1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "20", headers["/bk/img"].Get("Content-Length"))
+
+	body, info, err := client.ObjectGet("bk", "img")
+	assert.Nil(t, err)
+	data, _ := io.ReadAll(body)
+	body.Close()
+	assert.Equal(t, content, string(data))
+	assert.Equal(t, int64(20), info.Size)
+	assert.Equal(t, "image/png", info.ContentType)
+	assert.Equal(t, map[string]string{"camera": "cam-1"}, info.Metadata)
+
+	for _, ignore := range []bool{false, true} {
+		ignoreRange = ignore
+		body, info, err = client.ObjectGetRange("bk", "img", 5, 4)
+		assert.Nil(t, err)
+		data, _ = io.ReadAll(body)
+		body.Close()
+		assert.Equal(t, "5678", string(data))
+		assert.Equal(t, int64(20), info.Size)
+
+		body, _, err = client.ObjectGetRange("bk", "img", 15, -1)
+		assert.Nil(t, err)
+		data, _ = io.ReadAll(body)
+		body.Close()
+		assert.Equal(t, "fghij", string(data))
+	}
+
+	ignoreRange = false
+	_, _, err = client.ObjectGetRange("bk", "img", 30, 5)
+	assert.True(t, errors.Is(err, s3xErrors.ErrInvalidRange), "unexpected error: %v", err)
+	_, _, err = client.ObjectGet("bk", "missing")
+	assert.True(t, errors.Is(err, s3xErrors.ErrObjectNotExist), "unexpected error: %v", err)
+}
@@ -0,0 +1,143 @@
+package v1beta1
+
+import (
+	"context"
+	"sync"
+)
+
+// SetReadAhead - buffer stream reads in windows of window bytes, rounded up to object chunk size,
+// and prefetch next prefetch windows in parallel. window 0 (default) sends every Read to the server as is
+func SetReadAhead(window, prefetch int) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.readAheadWindow = window
+		edgex.readAheadPrefetch = prefetch
+	}
+}
+
+// readWindow - object range [start, start+len(data)) fetched by single request
+type readWindow struct {
+	start  int
+	done   chan struct{}
+	cancel context.CancelFunc
+	data   []byte
+	err    error
+}
+
+// readAhead - windows cache of one object stream
+type readAhead struct {
+	window   int
+	prefetch int
+
+	lock    sync.Mutex
+	windows map[int]*readWindow
+}
+
+func newReadAhead(window, prefetch, chunkSize int) *readAhead {
+	if chunkSize > 0 && window%chunkSize != 0 {
+		window = (window/chunkSize + 1) * chunkSize
+	}
+	if prefetch < 0 {
+		prefetch = 0
+	}
+	return &readAhead{
+		window:   window,
+		prefetch: prefetch,
+		windows:  make(map[int]*readWindow),
+	}
+}
+
+// fetch - window starting at start, requested from server if not cached yet
+func (ra *readAhead) fetch(s *s3xObjectStream, start int) *readWindow {
+	ra.lock.Lock()
+	defer ra.lock.Unlock()
+
+	if w, ok := ra.windows[start]; ok {
+		return w
+	}
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	w := &readWindow{start: start, done: make(chan struct{}), cancel: cancel}
+	ra.windows[start] = w
+
+	length := ra.window
+	if size := s.logicalSize(); start+length > size {
+		length = size - start
+	}
+	go func() {
+		defer close(w.done)
+		buf := make([]byte, length)
+		n, err := s.readAt(ctx, buf, start)
+		w.data, w.err = buf[:n], err
+	}()
+	return w
+}
+
+// read - copy object data at offset into p, fetching current window and prefetching next ones
+func (ra *readAhead) read(s *s3xObjectStream, p []byte, offset int) (int, error) {
+	start := offset / ra.window * ra.window
+	ra.retain(start)
+
+	w := ra.fetch(s, start)
+	size := s.logicalSize()
+	for i := 1; i <= ra.prefetch; i++ {
+		next := start + i*ra.window
+		if next >= size {
+			break
+		}
+		ra.fetch(s, next)
+	}
+
+	select {
+	case <-w.done:
+	case <-s.ctx.Done():
+		return 0, s.ctx.Err()
+	}
+	if w.err != nil {
+		ra.drop(w)
+		return 0, w.err
+	}
+	if offset-start >= len(w.data) {
+		return 0, nil
+	}
+	return copy(p, w.data[offset-start:]), nil
+}
+
+// retain - drop windows outside of read-ahead range starting at start
+func (ra *readAhead) retain(start int) {
+	end := start + (ra.prefetch+1)*ra.window
+	ra.invalidate(func(w *readWindow) bool {
+		return w.start < start || w.start >= end
+	})
+}
+
+// invalidateRange - drop windows overlapping [offset, offset+length)
+func (ra *readAhead) invalidateRange(offset, length int) {
+	ra.invalidate(func(w *readWindow) bool {
+		return w.start < offset+length && offset < w.start+ra.window
+	})
+}
+
+// reset - drop all windows
+func (ra *readAhead) reset() {
+	ra.invalidate(func(*readWindow) bool { return true })
+}
+
+func (ra *readAhead) invalidate(match func(*readWindow) bool) {
+	ra.lock.Lock()
+	defer ra.lock.Unlock()
+	for start, w := range ra.windows {
+		if match(w) {
+			w.cancel()
+			delete(ra.windows, start)
+		}
+	}
+}
+
+func (ra *readAhead) drop(w *readWindow) {
+	ra.lock.Lock()
+	defer ra.lock.Unlock()
+	if ra.windows[w.start] == w {
+		w.cancel()
+		delete(ra.windows, w.start)
+	}
+}
@@ -0,0 +1,46 @@
+package v1beta1
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReadAhead(t *testing.T) {
+	content := make([]byte, 100000)
+	rand.New(rand.NewSource(1)).Read(content)
+	server := newStreamServer()
+	server.objects["/bk/obj"] = append([]byte(nil), content...)
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	client, err := CreateEdgex(srv.URL, "", "", 0, SetReadAhead(10000, 2))
+	assert.Nil(t, err)
+	stream, err := client.ObjectGetStream("bk", "obj")
+	assert.Nil(t, err)
+	defer stream.Close()
+
+	// small reads are served from 12288 bytes windows aligned to 4096 chunk size
+	var out bytes.Buffer
+	_, err = io.CopyBuffer(&out, stream, make([]byte, 1000))
+	assert.Nil(t, err)
+	assert.Equal(t, content, out.Bytes())
+	assert.Equal(t, 9, server.reads)
+
+	// write through stream invalidates cached window
+	_, err = stream.Seek(50000, io.SeekStart)
+	assert.Nil(t, err)
+	_, err = stream.Write([]byte("0123456789"))
+	assert.Nil(t, err)
+	_, err = stream.Seek(49995, io.SeekStart)
+	assert.Nil(t, err)
+	buf := make([]byte, 20)
+	_, err = io.ReadFull(stream, buf)
+	assert.Nil(t, err)
+	assert.Equal(t, "0123456789", string(buf[5:15]))
+	assert.Equal(t, content[50010:50015], buf[15:])
+}
@@ -0,0 +1,59 @@
+package v1beta1
+
+import (
+	"io"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StreamReadWriteAt(t *testing.T) {
+	server := newStreamServer()
+	server.objects["/bk/obj"] = []byte("0123456789")
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	client, err := CreateEdgex(srv.URL, "", "", 0, SetWriteBehind(4096))
+	assert.Nil(t, err)
+	stream, err := client.ObjectGetStream("bk", "obj")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), stream.Size())
+
+	// buffered write is flushed before positional IO
+	_, err = stream.Write([]byte("ab"))
+	assert.Nil(t, err)
+
+	// concurrent writes share one session, stream offset stays put
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := stream.WriteAt([]byte{byte('A' + i)}, int64(10+i))
+			assert.Nil(t, err)
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 1, server.next)
+	assert.Equal(t, int64(18), stream.Size())
+
+	buf := make([]byte, 10)
+	n, err := stream.ReadAt(buf, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "ab23456789", string(buf[:n]))
+	n, err = stream.ReadAt(buf, 15)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, "FGH", string(buf[:n]))
+	pos, err := stream.Seek(0, io.SeekCurrent)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), pos)
+
+	// size seen by the stream session
+	size, err := stream.Stat()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(18), size)
+	assert.Nil(t, stream.Close())
+	assert.Equal(t, "ab23456789ABCDEFGH", string(server.objects["/bk/obj"]))
+}
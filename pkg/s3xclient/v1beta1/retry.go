@@ -0,0 +1,208 @@
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+)
+
+// RetryPolicy - defines how failed requests are replayed.
+// Only operations safe to replay are retried: reads, lists, whole object and multipart part PUTs
+// and autocommit k/v posts. Requests bound to a k/v or stream session (x-session-id header
+// or x-ccow-autocommit=0), stream writes, creates, copies, deletes and multipart
+// initiate/complete/abort are never replayed.
+type RetryPolicy struct {
+	// Total number of attempts, 1 disables retries
+	MaxAttempts int
+	// First backoff delay, doubled on every next attempt
+	BaseDelay time.Duration
+	// Backoff delay upper limit
+	MaxDelay time.Duration
+	// Response status codes treated as transient failures
+	RetryableStatus map[int]bool
+	// Transport errors classification, isRetryableError is used when nil
+	RetryableError func(err error) bool
+}
+
+// NoRetryPolicy - every request is sent once
+var NoRetryPolicy = RetryPolicy{MaxAttempts: 1}
+
+// DefaultRetryPolicy - recommended policy for S3X gateways, every call returns a new copy
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		RetryableStatus: map[int]bool{
+			http.StatusTooManyRequests:     true,
+			http.StatusInternalServerError: true,
+			http.StatusBadGateway:          true,
+			http.StatusServiceUnavailable:  true,
+			http.StatusGatewayTimeout:      true,
+		},
+	}
+}
+
+// SetRetryPolicy - replay transient failures according to policy
+func SetRetryPolicy(policy RetryPolicy) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.retryPolicy = policy
+	}
+}
+
+// replayableOps - operations with the same outcome when sent again
+var replayableOps = map[string]bool{
+	"BucketList":           true,
+	"BucketHead":           true,
+	"ObjectList":           true,
+	"ObjectHead":           true,
+	"ObjectStat":           true,
+	"ObjectGetStream":      true,
+	"ObjectGet":            true,
+	"ObjectPut":            true,
+	"ObjectDownload":       true,
+	"StreamRead":           true,
+	"StreamStat":           true,
+	"KeyValueGet":          true,
+	"KeyValueList":         true,
+	"KeyValuePost":         true,
+	"KeyValueMapPost":      true,
+	"KeyValuePostJSON":     true,
+	"KeyValuePostCSV":      true,
+	"MultipartUploadPart":  true,
+	"MultipartListParts":   true,
+	"MultipartListUploads": true,
+}
+
+// isReplayable - true if request of op may be sent again without changing its outcome
+func isReplayable(req *http.Request, op *operation) bool {
+	if !replayableOps[op.name] {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if req.Header.Get("x-session-id") != "" {
+		return false
+	}
+	if req.URL.Query().Get("x-ccow-autocommit") == "0" {
+		return false
+	}
+	return true
+}
+
+// isRetryableError - timeouts, connection resets, refused or failed dials and connections closed
+// mid-response. Certificate, URL and other transport errors fail the same way when sent again
+func isRetryableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// partRetryable - failed transfer part worth another attempt. API errors are retried if their status
+// is transient for the policy, or for DefaultRetryPolicy if the policy lists none
+func (policy *RetryPolicy) partRetryable(err error) bool {
+	var apiErr *s3xErrors.APIError
+	if errors.As(err, &apiErr) {
+		status := policy.RetryableStatus
+		if status == nil {
+			status = DefaultRetryPolicy().RetryableStatus
+		}
+		return status[apiErr.StatusCode]
+	}
+	if errors.Is(err, s3xErrors.ErrChecksum) {
+		return false
+	}
+	if policy.RetryableError != nil {
+		return policy.RetryableError(err)
+	}
+	return isRetryableError(err)
+}
+
+func (policy *RetryPolicy) shouldRetry(res *http.Response, err error) bool {
+	if err != nil {
+		if policy.RetryableError != nil {
+			return policy.RetryableError(err)
+		}
+		return isRetryableError(err)
+	}
+	return policy.RetryableStatus[res.StatusCode]
+}
+
+// backoff - exponential delay with full jitter, Retry-After header takes precedence.
+// Both are limited by MaxDelay
+func (policy *RetryPolicy) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if secs, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && secs >= 0 {
+			delay := time.Duration(secs) * time.Second
+			if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+				delay = policy.MaxDelay
+			}
+			return delay
+		}
+	}
+	delay := policy.BaseDelay << uint(attempt-1)
+	if delay <= 0 || (policy.MaxDelay > 0 && delay > policy.MaxDelay) {
+		delay = policy.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(delay)) + 1)
+}
+
+// doWithRetry - sends request, replaying it on transient failures if allowed by policy
+func (edgex *Edgex) doWithRetry(req *http.Request, op *operation) (*http.Response, error) {
+	policy := &edgex.retryPolicy
+	replayable := isReplayable(req, op)
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		res, err := edgex.send(req, op)
+		if !replayable || attempt >= policy.MaxAttempts || !policy.shouldRetry(res, err) {
+			return res, err
+		}
+
+		delay := policy.backoff(attempt, res)
+		edgex.logger.Warn("s3x request retry", op.logArgs("attempt", attempt, "delay", delay)...)
+		edgex.metrics.retry(op)
+		if res != nil {
+			io.Copy(ioutil.Discard, io.LimitReader(res.Body, 64*1024))
+			res.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package v1beta1
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyServer fails first `failures` requests with 503 and records request bodies
+func flakyServer(failures int32, bodies *[]string) (*httptest.Server, *int32) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		*bodies = append(*bodies, string(body))
+		if atomic.AddInt32(&calls, 1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("<Error><Code>SlowDown</Code></Error>"))
+			return
+		}
+		w.Header().Set("X-Session-Id", "sid-1")
+	}))
+	return srv, &calls
+}
+
+func retryClient(url string) *Edgex {
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	client, _ := CreateEdgex(url, "", "", 0, SetRetryPolicy(policy))
+	return client.(*Edgex)
+}
+
+func Test_RetryReplaysIdempotentRequests(t *testing.T) {
+	var bodies []string
+	srv, calls := flakyServer(2, &bodies)
+	defer srv.Close()
+	edgex := retryClient(srv.URL)
+
+	err := edgex.KeyValuePost("bk", "obj", "key1", bytes.NewBufferString("value1"), "", false)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	// body must be rewound for every attempt
+	assert.Equal(t, []string{"value1", "value1", "value1"}, bodies)
+}
+
+func Test_RetrySkipsOpenSession(t *testing.T) {
+	var bodies []string
+	srv, calls := flakyServer(1, &bodies)
+	defer srv.Close()
+	edgex := retryClient(srv.URL)
+	edgex.sessions.set("bk", "obj", "sid-0")
+
+	err := edgex.KeyValuePost("bk", "obj", "key1", bytes.NewBufferString("value1"), "", true)
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func Test_RetryGivesUp(t *testing.T) {
+	var bodies []string
+	srv, calls := flakyServer(100, &bodies)
+	defer srv.Close()
+	edgex := retryClient(srv.URL)
+
+	err := edgex.BucketHead("bk")
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(DefaultRetryPolicy().MaxAttempts), atomic.LoadInt32(calls))
+}
+
+func Test_RetrySkipsNonIdempotent(t *testing.T) {
+	var bodies []string
+	srv, calls := flakyServer(1, &bodies)
+	defer srv.Close()
+	edgex := retryClient(srv.URL)
+
+	// duplicate initiate would leave an orphan upload
+	_, err := edgex.MultipartInitiate("bk", "obj", "", nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+
+	// first stream write opens a session, replay would open another one
+	bodies = nil
+	srv2, calls2 := flakyServer(1, &bodies)
+	defer srv2.Close()
+	edgex = retryClient(srv2.URL)
+	stream := &s3xObjectStream{edgex: edgex, ctx: context.Background(), bucket: "bk", object: "obj", path: "/bk/obj"}
+	assert.NotNil(t, stream.writeAt([]byte("data"), 0))
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls2))
+}
+
+func Test_RetrySkipsPermanentTransportErrors(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	// certificate of the test server is not trusted by default transport
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	transport := &countingTransport{}
+	client, err := CreateEdgex(srv.URL, "", "", 0, SetRetryPolicy(policy), SetHTTPClient(&http.Client{Transport: transport}))
+	assert.Nil(t, err)
+	err = client.BucketHead("bk")
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, transport.requests)
+
+	assert.False(t, isRetryableError(&url.Error{Op: "Get", URL: "s3x://bk", Err: errors.New("unsupported protocol scheme")}))
+	assert.True(t, isRetryableError(&url.Error{Op: "Get", URL: srv.URL, Err: &net.OpError{Op: "dial", Err: errors.New("no route to host")}}))
+	assert.True(t, isRetryableError(&url.Error{Op: "Get", URL: srv.URL, Err: &net.DNSError{IsTimeout: true}}))
+	assert.True(t, isRetryableError(&url.Error{Op: "Get", URL: srv.URL, Err: syscall.ECONNRESET}))
+}
+
+func Test_RetryAfterLimitedByMaxDelay(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.MaxDelay = 10 * time.Millisecond
+	res := &http.Response{Header: http.Header{"Retry-After": []string{"3600"}}}
+	assert.Equal(t, policy.MaxDelay, policy.backoff(1, res))
+
+	client, err := CreateEdgex(srv.URL, "", "", 0, SetRetryPolicy(policy))
+	assert.Nil(t, err)
+	begin := time.Now()
+	assert.Nil(t, client.BucketHead("bk"))
+	assert.Less(t, time.Since(begin), 5*time.Second)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func Test_DefaultRetryPolicyCopy(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	policy.RetryableStatus[http.StatusNotFound] = true
+	assert.False(t, DefaultRetryPolicy().RetryableStatus[http.StatusNotFound])
+}
@@ -8,6 +8,9 @@ import (
 	"time"
 
 	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Edgex - Edgex is S3xClient implementation
@@ -15,23 +18,56 @@ type Edgex struct {
 	//Parsed url contains scheme, host(:port) only i.e scheme://host:[port]
 	baseUrl    *url.URL
 	httpClient *http.Client
+	transport  transportConfig
+
+	// Gateway nodes, baseUrl is the first of them
+	endpoints           *endpointPool
+	balancePolicy       BalancePolicy
+	healthCheckInterval time.Duration
 
 	// s3 authentication keys
 	Authkey string
 	Secret  string
 
-	// Debug level
-	Debug int
+	// AWS Signature V4 settings, used when Authkey is set
+	signingRegion       string
+	signingService      string
+	signatureStyle      SignatureStyle
+	presignExpires      time.Duration
+	unsignedPayloadSize int64
+
+	// Transient failures replay policy
+	retryPolicy RetryPolicy
+
+	// Structured logger, silent by default
+	logger Logger
+
+	// Prometheus metrics, disabled by default
+	metrics           *metrics
+	metricsRegisterer prometheus.Registerer
+
+	// OpenTelemetry tracing, disabled by default
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+
+	// Stream read-ahead, disabled by default
+	readAheadWindow   int
+	readAheadPrefetch int
+	// Stream write coalescing, disabled by default
+	writeBehindSize int
+
+	// User middlewares around every HTTP attempt
+	middlewares []Middleware
+	roundTrip   RoundTripFunc
 
-	// Should move to Tx struct
-	Sid string
+	// Implicit k/v sessions of KeyValue* methods, see BeginKV for explicit transactions
+	sessions kvSessions
 }
 
 //getValidUrl: returns S3X endpoint w/o path and parameters
 func getValidUrl(s3xurl string) (*url.URL, error) {
 	u, err := url.Parse(s3xurl)
 	if err != nil {
-		fmt.Printf("Error: %+v", err)
 		return nil, err
 	}
 
@@ -52,27 +88,89 @@ func getValidUrl(s3xurl string) (*url.URL, error) {
 	return u, nil
 }
 
+// Edgex implements both plain and context-first client interfaces
+var (
+	_ s3xApi.S3xClient    = (*Edgex)(nil)
+	_ s3xApi.S3xClientCtx = (*Edgex)(nil)
+)
+
 type EdgexOption func(*Edgex)
 
+// SetHTTPClient - use custom http client, transport options are ignored in this case
 func SetHTTPClient(httpClient *http.Client) EdgexOption {
 	return func(edgex *Edgex) {
 		edgex.httpClient = httpClient
 	}
 }
 
+// SetSigningRegion - region used in AWS Signature V4 credential scope. Default is us-east-1
+func SetSigningRegion(region string) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.signingRegion = region
+	}
+}
+
+// SetSigningService - service used in AWS Signature V4 credential scope. Default is s3
+func SetSigningService(service string) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.signingService = service
+	}
+}
+
+// SetSignatureStyle - sign requests with Authorization header (default) or presigned query parameters
+func SetSignatureStyle(style SignatureStyle) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.signatureStyle = style
+	}
+}
+
+// SetPresignExpires - X-Amz-Expires value for query signature style
+func SetPresignExpires(expires time.Duration) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.presignExpires = expires
+	}
+}
+
+// SetUnsignedPayloadSize - request bodies larger than size are sent with UNSIGNED-PAYLOAD
+// instead of hashing them, i.e. for large stream writes
+func SetUnsignedPayloadSize(size int64) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.unsignedPayloadSize = size
+	}
+}
+
 // CreateEdgex - S3X client factory
-func CreateEdgex(s3xurl, authkey, secret string, debug int, options ...EdgexOption) (s3xApi.S3xClient, error) {
-	url, err := getValidUrl(s3xurl)
+func CreateEdgex(s3xurl, authkey, secret string, debug int, options ...EdgexOption) (s3xApi.S3xClientCtx, error) {
+	baseUrl, err := getValidUrl(s3xurl)
 	if err != nil {
 		return nil, err
 	}
+	return createEdgex([]*url.URL{baseUrl}, authkey, secret, debug, options...)
+}
 
+// createEdgex - client of one or several endpoints, the first one is used to build request urls
+func createEdgex(urls []*url.URL, authkey, secret string, debug int, options ...EdgexOption) (s3xApi.S3xClientCtx, error) {
+	var err error
 	edgex := Edgex{
-		baseUrl:    url,
-		Authkey:    authkey,
-		Secret:     secret,
-		Debug:      debug,
-		httpClient: &http.Client{Timeout: 45 * time.Second},
+		baseUrl:   urls[0],
+		endpoints: newEndpointPool(urls),
+		Authkey:   authkey,
+		Secret:    secret,
+		transport: defaultTransportConfig(),
+
+		signingRegion:       defaultSigningRegion,
+		signingService:      defaultSigningService,
+		signatureStyle:      SignatureHeader,
+		presignExpires:      defaultPresignExpires,
+		unsignedPayloadSize: defaultUnsignedPayloadSize,
+		retryPolicy:         NoRetryPolicy,
+		logger:              nopLogger{},
+		healthCheckInterval: defaultHealthCheckInterval,
+	}
+
+	// debug level is kept for compatibility: any positive value enables stderr debug logging
+	if debug > 0 {
+		edgex.logger = debugLogger()
 	}
 
 	// apply all options handlers to edgex instance
@@ -80,9 +178,99 @@ func CreateEdgex(s3xurl, authkey, secret string, debug int, options ...EdgexOpti
 		options[i](&edgex)
 	}
 
+	// all operations share single pooled transport
+	if edgex.httpClient == nil {
+		edgex.httpClient, err = edgex.transport.newHTTPClient()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if edgex.metricsRegisterer != nil {
+		edgex.metrics, err = newMetrics(edgex.metricsRegisterer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	edgex.roundTrip = edgex.buildRoundTrip()
+	edgex.endpoints.policy = edgex.balancePolicy
+	if len(urls) > 1 && edgex.healthCheckInterval > 0 {
+		go edgex.healthCheck(edgex.healthCheckInterval)
+	}
+
 	return &edgex, nil
 }
 
+// do - sends request of operation op through configured http client, replaying transient failures
+func (edgex *Edgex) do(req *http.Request, op *operation) (*http.Response, error) {
+	req, span := edgex.startOperationSpan(req, op)
+	start := time.Now()
+	res, err := edgex.doWithRetry(req, op)
+	edgex.metrics.observe(op, req, res, err, time.Since(start))
+	edgex.endOperationSpan(span, op, res, err)
+	return res, err
+}
+
+// send - routes request to endpoint and performs single http attempt through middlewares.
+// Requests outside of sessions fail over to another endpoint if connection can't be established
+func (edgex *Edgex) send(req *http.Request, op *operation) (*http.Response, error) {
+	pin := sessionPin(req)
+	for failover := 1; ; failover++ {
+		node, pinned := edgex.endpoints.pick(pin)
+		node.route(req)
+		// trace context headers are injected before signing
+		span := edgex.startAttemptSpan(req, node)
+
+		edgex.logger.Debug("s3x request", op.logArgs("method", req.Method, "path", req.URL.Path)...)
+		start := time.Now()
+		res, err := edgex.roundTrip(req, op.requestInfo(req))
+		latency := time.Since(start)
+		edgex.endAttemptSpan(span, res, err)
+		if err != nil {
+			if !isDialError(err) || len(edgex.endpoints.nodes) == 1 {
+				edgex.logger.Error("s3x request failed", op.logArgs("latency", latency, "error", err)...)
+				return nil, err
+			}
+			if !node.unhealthy.Swap(true) {
+				edgex.logger.Warn("s3x endpoint is down", "endpoint", node.url.Host, "error", err)
+			}
+			if pinned || failover >= len(edgex.endpoints.nodes) || (req.Body != nil && req.GetBody == nil) {
+				edgex.logger.Error("s3x request failed", op.logArgs("latency", latency, "endpoint", node.url.Host, "error", err)...)
+				return nil, err
+			}
+			if req.GetBody != nil {
+				if req.Body, err = req.GetBody(); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		if node.unhealthy.Swap(false) {
+			edgex.logger.Info("s3x endpoint is up", "endpoint", node.url.Host)
+		}
+		node.observe(latency)
+		edgex.endpoints.track(pin, node, req, res)
+		edgex.logResponse(op, res, latency, node)
+		return res, nil
+	}
+}
+
+func (edgex *Edgex) logResponse(op *operation, res *http.Response, latency time.Duration, node *endpoint) {
+	args := op.logArgs("status", res.StatusCode, "latency", latency)
+	if len(edgex.endpoints.nodes) > 1 {
+		args = append(args, "endpoint", node.url.Host)
+	}
+	if sid := res.Header.Get("X-Session-Id"); sid != "" && sid != op.session {
+		args = append(args, "new_session_id", sid)
+	}
+	if res.StatusCode >= 500 {
+		edgex.logger.Warn("s3x response", args...)
+	} else {
+		edgex.logger.Debug("s3x response", args...)
+	}
+}
+
 type S3XURLOptions map[string]string
 type S3XURL struct {
 	url.URL
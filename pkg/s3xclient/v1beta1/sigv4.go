@@ -0,0 +1,218 @@
+package v1beta1
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureStyle - where AWS Signature V4 is placed in a request
+type SignatureStyle int
+
+const (
+	// SignatureHeader - signature is sent in Authorization header
+	SignatureHeader SignatureStyle = iota
+	// SignatureQuery - signature is sent as presigned X-Amz-* query parameters
+	SignatureQuery
+)
+
+const (
+	signV4Algorithm  = "AWS4-HMAC-SHA256"
+	unsignedPayload  = "UNSIGNED-PAYLOAD"
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	iso8601Format    = "20060102T150405Z"
+	yyyymmddFormat   = "20060102"
+
+	defaultSigningRegion             = "us-east-1"
+	defaultSigningService            = "s3"
+	defaultPresignExpires            = 15 * time.Minute
+	defaultUnsignedPayloadSize int64 = 1024 * 1024
+)
+
+// headers which are never part of signature: they can be changed by proxies or transport
+var ignoredSigningHeaders = map[string]bool{
+	"Authorization":   true,
+	"Content-Length":  true,
+	"User-Agent":      true,
+	"Accept-Encoding": true,
+	"Connection":      true,
+	"Expect":          true,
+}
+
+// timeNow is replaced in tests to get reproducible signatures
+var timeNow = time.Now
+
+// signRequest - sign request with AWS Signature V4 using Authkey/Secret.
+// Requests are left unsigned when no Authkey is configured.
+func (edgex *Edgex) signRequest(req *http.Request) error {
+	if edgex.Authkey == "" {
+		return nil
+	}
+
+	now := timeNow().UTC()
+	amzDate := now.Format(iso8601Format)
+	scope := strings.Join([]string{now.Format(yyyymmddFormat), edgex.signingRegion, edgex.signingService, "aws4_request"}, "/")
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	if edgex.signatureStyle == SignatureQuery {
+		q := req.URL.Query()
+		q.Del("X-Amz-Signature")
+		q.Set("X-Amz-Algorithm", signV4Algorithm)
+		q.Set("X-Amz-Credential", edgex.Authkey+"/"+scope)
+		q.Set("X-Amz-Date", amzDate)
+		q.Set("X-Amz-Expires", strconv.Itoa(int(edgex.presignExpires/time.Second)))
+		q.Set("X-Amz-SignedHeaders", "host")
+		req.URL.RawQuery = canonicalQuery(q)
+
+		canonical := canonicalRequest(req, "host:"+host+"\n", "host", unsignedPayload)
+		signature := edgex.signature(now, scope, amzDate, canonical)
+		req.URL.RawQuery += "&X-Amz-Signature=" + signature
+		return nil
+	}
+
+	payloadHash, err := edgex.payloadHash(req)
+	if err != nil {
+		return fmt.Errorf("Request sign payload error: %v", err)
+	}
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers, signedHeaders := canonicalHeaders(req, host)
+	canonical := canonicalRequest(req, headers, signedHeaders, payloadHash)
+	signature := edgex.signature(now, scope, amzDate, canonical)
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signV4Algorithm, edgex.Authkey, scope, signedHeaders, signature))
+	return nil
+}
+
+// payloadHash - hex encoded SHA256 of request body.
+// Bodies larger than unsignedPayloadSize (i.e. big stream writes) are sent as UNSIGNED-PAYLOAD
+func (edgex *Edgex) payloadHash(req *http.Request) (string, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return emptyPayloadHash, nil
+	}
+	if req.ContentLength > edgex.unsignedPayloadSize || req.GetBody == nil {
+		return unsignedPayload, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, body); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (edgex *Edgex) signature(now time.Time, scope, amzDate, canonical string) string {
+	stringToSign := strings.Join([]string{signV4Algorithm, amzDate, scope, hashHex([]byte(canonical))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+edgex.Secret), now.Format(yyyymmddFormat))
+	key = hmacSHA256(key, edgex.signingRegion)
+	key = hmacSHA256(key, edgex.signingService)
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func canonicalRequest(req *http.Request, headers, signedHeaders, payloadHash string) string {
+	return strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path, false),
+		canonicalQuery(req.URL.Query()),
+		headers,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+}
+
+// canonicalHeaders returns canonical header block and ';' separated list of signed headers
+func canonicalHeaders(req *http.Request, host string) (string, string) {
+	values := map[string][]string{"host": {host}}
+	for name, v := range req.Header {
+		if ignoredSigningHeaders[http.CanonicalHeaderKey(name)] {
+			continue
+		}
+		lname := strings.ToLower(name)
+		values[lname] = append(values[lname], v...)
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b bytes.Buffer
+	for _, name := range names {
+		trimmed := make([]string, len(values[name]))
+		for i, v := range values[name] {
+			trimmed[i] = strings.Join(strings.Fields(v), " ")
+		}
+		b.WriteString(name)
+		b.WriteString(":")
+		b.WriteString(strings.Join(trimmed, ","))
+		b.WriteString("\n")
+	}
+	return b.String(), strings.Join(names, ";")
+}
+
+func canonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(q))
+	for _, k := range keys {
+		vals := append([]string(nil), q[k]...)
+		sort.Strings(vals)
+		for _, v := range vals {
+			pairs = append(pairs, uriEncode(k, true)+"="+uriEncode(v, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode - RFC 3986 encoding as required by AWS: only unreserved characters are kept
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || (c == '/' && !encodeSlash) {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func hashHex(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,119 @@
+package v1beta1
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+	"github.com/highpeakdata/edgex-go-connector/pkg/utils"
+)
+
+const (
+	headerLogicalSize = "x-ccow-logical-size"
+	headerChunkSize   = "x-ccow-chunkmap-chunk-size"
+	headerBTreeOrder  = "x-ccow-chunkmap-btree-order"
+	headerChunkmap    = "x-ccow-chunkmap-type"
+	headerGeneration  = "x-ccow-generation"
+	headerVersionID   = "x-amz-version-id"
+	headerMetaPrefix  = "x-amz-meta-"
+
+	// chunkmap type of key/value objects
+	chunkmapKeyValue = "btree_key_val"
+)
+
+// ObjectStat - read object size, type, layout and metadata
+func (edgex *Edgex) ObjectStat(bucket, object string) (s3xApi.ObjectInfo, error) {
+	return edgex.ObjectStatCtx(context.Background(), bucket, object)
+}
+
+// ObjectStatCtx - read object size, type, layout and metadata within ctx
+func (edgex *Edgex) ObjectStatCtx(ctx context.Context, bucket, object string) (s3xApi.ObjectInfo, error) {
+	objectPath, err := utils.GetObjectPath(bucket, object)
+	if err != nil {
+		return s3xApi.ObjectInfo{}, err
+	}
+
+	s3xurl := edgex.newS3xURL(objectPath)
+	s3xurl.AddOptions(S3XURLOptions{
+		"comp":   "streamsession",
+		"cancel": "",
+	})
+
+	req, err := http.NewRequestWithContext(ctx, "HEAD", s3xurl.String(), nil)
+	if err != nil {
+		return s3xApi.ObjectInfo{}, err
+	}
+
+	res, err := edgex.do(req, &operation{name: "ObjectStat", bucket: bucket, object: object})
+	if err != nil {
+		return s3xApi.ObjectInfo{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return s3xApi.ObjectInfo{}, s3xErrors.NewAPIError(res, "ObjectStat", bucket, object, "")
+	}
+	return objectInfo(bucket, object, res.Header), nil
+}
+
+// objectInfo - object info from HEAD response headers
+func objectInfo(bucket, object string, header http.Header) s3xApi.ObjectInfo {
+	info := s3xApi.ObjectInfo{
+		Bucket:      bucket,
+		Object:      object,
+		ContentType: header.Get("Content-Type"),
+		ETag:        strings.Trim(header.Get("ETag"), `"`),
+		ObjectType:  s3xApi.OBJECT_TYPE_OBJECT,
+		ChunkSize:   s3xApi.DEFAULT_CHUNKSIZE,
+		BTreeOrder:  s3xApi.DEFAULT_BTREE_ORDER,
+		VersionID:   header.Get(headerVersionID),
+	}
+	if size, err := strconv.ParseInt(header.Get(headerLogicalSize), 10, 64); err == nil {
+		info.Size = size
+	} else if total := contentRangeTotal(header.Get("Content-Range")); total >= 0 {
+		info.Size = total
+	} else {
+		info.Size, _ = strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+	}
+	if modified, err := http.ParseTime(header.Get("Last-Modified")); err == nil {
+		info.LastModified = modified.In(time.UTC)
+	}
+	if header.Get(headerChunkmap) == chunkmapKeyValue {
+		info.ObjectType = s3xApi.OBJECT_TYPE_KEY_VALUE
+	}
+	if chunkSize, err := strconv.Atoi(header.Get(headerChunkSize)); err == nil && chunkSize > 0 {
+		info.ChunkSize = chunkSize
+	}
+	if order, err := strconv.Atoi(header.Get(headerBTreeOrder)); err == nil && order > 0 {
+		info.BTreeOrder = order
+	}
+	info.Generation, _ = strconv.ParseUint(header.Get(headerGeneration), 10, 64)
+
+	for name, values := range header {
+		name = strings.ToLower(name)
+		if strings.HasPrefix(name, headerMetaPrefix) && len(values) > 0 {
+			if info.Metadata == nil {
+				info.Metadata = make(map[string]string)
+			}
+			info.Metadata[strings.TrimPrefix(name, headerMetaPrefix)] = values[0]
+		}
+	}
+	return info
+}
+
+// contentRangeTotal - complete length of "bytes first-last/total" content range, -1 if unknown
+func contentRangeTotal(contentRange string) int64 {
+	i := strings.LastIndex(contentRange, "/")
+	if i < 0 {
+		return -1
+	}
+	total, err := strconv.ParseInt(contentRange[i+1:], 10, 64)
+	if err != nil {
+		return -1
+	}
+	return total
+}
@@ -0,0 +1,59 @@
+package v1beta1
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ObjectStat(t *testing.T) {
+	modified := time.Date(2023, 5, 1, 10, 20, 30, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bk/obj" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "HEAD", r.Method)
+		h := w.Header()
+		h.Set("Content-Type", "application/json")
+		h.Set("ETag", `"5d41402abc4b2a76b9719d911017c592"`)
+		h.Set("Last-Modified", modified.Format(http.TimeFormat))
+		h.Set("x-ccow-logical-size", "123456")
+		h.Set("x-ccow-chunkmap-type", "btree_key_val")
+		h.Set("x-ccow-chunkmap-chunk-size", "8192")
+		h.Set("x-ccow-chunkmap-btree-order", "8")
+		h.Set("x-ccow-generation", "7")
+		h.Set("x-amz-version-id", "v7")
+		h.Set("x-amz-meta-camera", "cam-3")
+	}))
+	defer srv.Close()
+
+	client, err := CreateEdgex(srv.URL, "", "", 0)
+	assert.Nil(t, err)
+
+	info, err := client.ObjectStat("bk", "obj")
+	assert.Nil(t, err)
+	assert.Equal(t, s3xApi.ObjectInfo{
+		Bucket:       "bk",
+		Object:       "obj",
+		Size:         123456,
+		ContentType:  "application/json",
+		ETag:         "5d41402abc4b2a76b9719d911017c592",
+		LastModified: modified,
+		ObjectType:   s3xApi.OBJECT_TYPE_KEY_VALUE,
+		ChunkSize:    8192,
+		BTreeOrder:   8,
+		VersionID:    "v7",
+		Generation:   7,
+		Metadata:     map[string]string{"camera": "cam-3"},
+	}, info)
+
+	_, err = client.ObjectStat("bk", "missing")
+	assert.True(t, errors.Is(err, s3xErrors.ErrObjectNotExist), "unexpected error: %v", err)
+}
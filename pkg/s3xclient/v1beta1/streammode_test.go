@@ -0,0 +1,49 @@
+package v1beta1
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StreamModes(t *testing.T) {
+	server := newStreamServer()
+	server.objects["/bk/log"] = []byte("line1\n")
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	client, err := CreateEdgex(srv.URL, "", "", 0)
+	assert.Nil(t, err)
+
+	// append-only stream writes at the end whatever offset is
+	stream, err := client.ObjectGetStream("bk", "log", s3xApi.SS_APPEND)
+	assert.Nil(t, err)
+	_, err = stream.Seek(2, io.SeekStart)
+	assert.Nil(t, err)
+	_, err = stream.Write([]byte("line2\n"))
+	assert.Nil(t, err)
+	_, err = stream.WriteAt([]byte("x"), 0)
+	assert.True(t, errors.Is(err, s3xErrors.ErrStreamMode), "unexpected error: %v", err)
+	assert.Nil(t, stream.Close())
+	assert.Equal(t, "line1\nline2\n", string(server.objects["/bk/log"]))
+
+	stream, err = client.ObjectGetStream("bk", "log", s3xApi.SS_RDONLY)
+	assert.Nil(t, err)
+	_, err = stream.Write([]byte("line3\n"))
+	assert.True(t, errors.Is(err, s3xErrors.ErrStreamMode), "unexpected error: %v", err)
+	_, err = stream.WriteAt([]byte("x"), 0)
+	assert.True(t, errors.Is(err, s3xErrors.ErrStreamMode), "unexpected error: %v", err)
+	data, err := io.ReadAll(stream)
+	assert.Nil(t, err)
+	assert.Equal(t, "line1\nline2\n", string(data))
+	assert.Nil(t, stream.Close())
+	assert.Equal(t, 1, server.writes)
+
+	_, err = client.ObjectGetStream("bk", "log", s3xApi.SS_APPEND, s3xApi.SS_RDONLY)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,101 @@
+package v1beta1
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// streamServer keeps stream objects in memory and counts GET/POST and session finishing requests.
+// Writes are staged in session returned in X-Session-Id until it is finalized
+type streamServer struct {
+	lock     sync.Mutex
+	objects  map[string][]byte
+	sessions map[string][]byte
+	next     int
+	reads    int
+	writes   int
+	finishes int
+	// status returned for POST, 0 means success
+	writeStatus int
+}
+
+func newStreamServer() *streamServer {
+	return &streamServer{objects: map[string][]byte{}, sessions: map[string][]byte{}}
+}
+
+func (s *streamServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	sid := r.Header.Get("x-session-id")
+	data, exists := s.objects[r.URL.Path]
+	if staged, ok := s.sessions[sid]; ok {
+		data = staged
+	}
+	offset, _ := strconv.Atoi(r.Header.Get("x-ccow-offset"))
+	length, _ := strconv.Atoi(r.Header.Get("x-ccow-length"))
+	q := r.URL.Query()
+	switch r.Method {
+	case "HEAD":
+		if q.Has("finalize") || q.Has("cancel") {
+			s.finishes++
+		}
+		if _, ok := q["finalize"]; ok && sid != "" {
+			s.objects[r.URL.Path] = data
+			delete(s.sessions, sid)
+			return
+		}
+		if _, ok := q["cancel"]; ok && sid != "" {
+			delete(s.sessions, sid)
+			return
+		}
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("x-ccow-logical-size", strconv.Itoa(len(data)))
+		w.Header().Set("x-ccow-chunkmap-chunk-size", "4096")
+	case "GET":
+		s.reads++
+		if offset > len(data) {
+			offset = len(data)
+		}
+		end := offset + length
+		if end > len(data) {
+			end = len(data)
+		}
+		w.Write(data[offset:end])
+	case "POST":
+		if _, ok := q["finalize"]; ok {
+			// ObjectCreate
+			s.objects[r.URL.Path] = []byte{}
+			return
+		}
+		s.writes++
+		if s.writeStatus != 0 {
+			w.WriteHeader(s.writeStatus)
+			return
+		}
+		if sid == "" {
+			s.next++
+			sid = fmt.Sprintf("stream-%d", s.next)
+			data = append([]byte(nil), data...)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if offset+len(body) > len(data) {
+			data = append(data, make([]byte, offset+len(body)-len(data))...)
+		}
+		copy(data[offset:], body)
+		if size, err := strconv.Atoi(r.Header.Get("x-ccow-logical-size")); err == nil {
+			if size > len(data) {
+				data = append(data, make([]byte, size-len(data))...)
+			}
+			data = data[:size]
+		}
+		s.sessions[sid] = data
+		w.Header().Set("X-Session-Id", sid)
+	}
+}
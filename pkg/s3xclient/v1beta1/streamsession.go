@@ -0,0 +1,155 @@
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+	"github.com/highpeakdata/edgex-go-connector/pkg/utils"
+)
+
+// sessionID - server stream session id, empty until first write
+func (s *s3xObjectStream) sessionID() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.sid
+}
+
+func (s *s3xObjectStream) setSessionID(sid string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.sid = sid
+}
+
+// bindSession - bind stream request to write session.
+// Requests outside of write session are cancelled right away, so they don't leave session open
+func (s *s3xObjectStream) bindSession(req *http.Request) {
+	q := req.URL.Query()
+	q.Set("comp", "streamsession")
+	if sid := s.sessionID(); sid != "" {
+		req.Header.Set("x-session-id", sid)
+	} else if req.Method == "GET" {
+		q.Set("cancel", "")
+	}
+	req.URL.RawQuery = q.Encode()
+}
+
+// finishSession - finalize or cancel stream session sid of bucket/object
+func (edgex *Edgex) finishSession(ctx context.Context, op, bucket, object, sid string, options S3XURLOptions) error {
+	objectPath, err := utils.GetObjectPath(bucket, object)
+	if err != nil {
+		return err
+	}
+
+	s3xurl := edgex.newS3xURL(objectPath)
+	s3xurl.AddOptions(S3XURLOptions{
+		"comp": "streamsession",
+	})
+	s3xurl.AddOptions(options)
+
+	req, err := http.NewRequestWithContext(ctx, "HEAD", s3xurl.String(), nil)
+	if err != nil {
+		return err
+	}
+	if sid != "" {
+		req.Header.Add("x-session-id", sid)
+	}
+
+	res, err := edgex.do(req, &operation{name: op, bucket: bucket, object: object, session: sid})
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 300 {
+		return nil
+	}
+	return s3xErrors.NewAPIError(res, op, bucket, object, "")
+}
+
+// Close - flush buffered writes and finalize stream session, persisting new object version
+// if stream was written. Deferred write errors are returned here
+func (s *s3xObjectStream) Close() error {
+	if !s.markClosed() {
+		return nil
+	}
+	err := s.Flush()
+	if s.readAhead != nil {
+		s.readAhead.reset()
+	}
+	if err != nil {
+		// keep partial writes uncommitted, ctx may be cancelled already
+		if sid := s.sessionID(); sid != "" {
+			cancelErr := s.edgex.finishSession(context.Background(), "StreamAbort", s.bucket, s.object, sid, S3XURLOptions{
+				"cancel": "1",
+			})
+			return errors.Join(err, cancelErr)
+		}
+		return err
+	}
+
+	if s.isDirty() {
+		return s.edgex.finishSession(s.ctx, "StreamClose", s.bucket, s.object, s.sessionID(), S3XURLOptions{
+			"finalize": "",
+		})
+	}
+	if sid := s.sessionID(); sid != "" {
+		return s.edgex.finishSession(s.ctx, "StreamClose", s.bucket, s.object, sid, S3XURLOptions{
+			"cancel": "1",
+		})
+	}
+	return nil
+}
+
+// Abort - discard buffered and uncommitted writes and close stream
+func (s *s3xObjectStream) Abort() error {
+	if !s.markClosed() {
+		return nil
+	}
+	if s.writeBehind != nil {
+		s.bufLock.Lock()
+		s.writeBehind.data = nil
+		s.bufLock.Unlock()
+	}
+	if s.readAhead != nil {
+		s.readAhead.reset()
+	}
+
+	// writes buffered only were never sent, there is no session to cancel
+	sid := s.sessionID()
+	if sid == "" {
+		return nil
+	}
+	return s.edgex.finishSession(s.ctx, "StreamAbort", s.bucket, s.object, sid, S3XURLOptions{
+		"cancel": "1",
+	})
+}
+
+// checkOpen - closed stream can't be used anymore
+func (s *s3xObjectStream) checkOpen() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		return s3xErrors.ErrStreamClosed
+	}
+	return nil
+}
+
+// markClosed - mark stream closed, false if it was closed already
+func (s *s3xObjectStream) markClosed() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		return false
+	}
+	s.closed = true
+	return true
+}
+
+// isDirty - stream was written or truncated
+func (s *s3xObjectStream) isDirty() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.dirty
+}
@@ -0,0 +1,84 @@
+package v1beta1
+
+import (
+	"errors"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StreamSession(t *testing.T) {
+	server := newStreamServer()
+	server.objects["/bk/obj"] = []byte("hello world")
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	client, err := CreateEdgex(srv.URL, "", "", 0)
+	assert.Nil(t, err)
+
+	// writes share one session and become visible on Close
+	stream, err := client.ObjectGetStream("bk", "obj")
+	assert.Nil(t, err)
+	_, err = stream.Write([]byte("HELLO"))
+	assert.Nil(t, err)
+	_, err = stream.Write([]byte(" WORLD"))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(server.sessions))
+	assert.Equal(t, "hello world", string(server.objects["/bk/obj"]))
+	assert.Nil(t, stream.Close())
+	assert.Equal(t, "HELLO WORLD", string(server.objects["/bk/obj"]))
+	assert.Equal(t, 0, len(server.sessions))
+
+	_, err = stream.Write([]byte("late"))
+	assert.True(t, errors.Is(err, s3xErrors.ErrStreamClosed))
+
+	// aborted writes are discarded
+	stream, err = client.ObjectGetStream("bk", "obj")
+	assert.Nil(t, err)
+	_, err = stream.Write([]byte("xxxxx"))
+	assert.Nil(t, err)
+	assert.Nil(t, stream.Abort())
+	assert.Equal(t, "HELLO WORLD", string(server.objects["/bk/obj"]))
+	assert.Equal(t, 0, len(server.sessions))
+}
+
+func Test_StreamCloseConcurrent(t *testing.T) {
+	server := newStreamServer()
+	server.objects["/bk/obj"] = []byte("hello world")
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	client, err := CreateEdgex(srv.URL, "", "", 0, SetWriteBehind(4096))
+	assert.Nil(t, err)
+	stream, err := client.ObjectGetStream("bk", "obj")
+	assert.Nil(t, err)
+	_, err = stream.Write([]byte("HELLO"))
+	assert.Nil(t, err)
+
+	// readers racing with Close either see the data or a closed stream
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p := make([]byte, 5)
+			for j := 0; j < 10; j++ {
+				if _, err := stream.ReadAt(p, 0); err != nil {
+					assert.True(t, errors.Is(err, s3xErrors.ErrStreamClosed), "unexpected error: %v", err)
+					return
+				}
+				assert.Equal(t, "HELLO", string(p))
+			}
+		}()
+	}
+	closeErr := make(chan error, 2)
+	go func() { closeErr <- stream.Close() }()
+	go func() { closeErr <- stream.Close() }()
+	wg.Wait()
+	assert.Nil(t, <-closeErr)
+	assert.Nil(t, <-closeErr)
+	assert.Equal(t, 0, len(server.sessions))
+}
@@ -0,0 +1,168 @@
+package v1beta1
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/highpeakdata/edgex-go-connector/pkg/s3xclient/v1beta1"
+
+// SetTracerProvider - create span per S3X operation and per HTTP attempt using provider.
+// Trace context is propagated to the gateway in W3C traceparent headers unless SetPropagator is given
+func SetTracerProvider(provider trace.TracerProvider) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.tracer = provider.Tracer(tracerName)
+	}
+}
+
+// SetPropagator - trace context propagation format, used with SetTracerProvider
+func SetPropagator(propagator propagation.TextMapPropagator) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.propagator = propagator
+	}
+}
+
+// startOperationSpan - span of S3X operation, ended when response body is closed.
+// Returns request bound to span context
+func (edgex *Edgex) startOperationSpan(req *http.Request, op *operation) (*http.Request, trace.Span) {
+	if edgex.tracer == nil {
+		return req, nil
+	}
+
+	attrs := []attribute.KeyValue{attribute.String("s3x.operation", op.name)}
+	if op.bucket != "" {
+		attrs = append(attrs, attribute.String("s3x.bucket", op.bucket))
+	}
+	if op.object != "" {
+		attrs = append(attrs, attribute.String("s3x.object", op.object))
+	}
+	if op.key != "" {
+		attrs = append(attrs, attribute.String("s3x.key", op.key))
+	}
+	if op.keys > 0 {
+		attrs = append(attrs, attribute.Int("s3x.key_count", op.keys))
+	}
+	if op.session != "" {
+		attrs = append(attrs, attribute.String("s3x.session_id", op.session))
+	}
+	if req.ContentLength > 0 {
+		attrs = append(attrs, attribute.Int64("s3x.bytes_sent", req.ContentLength))
+	}
+
+	ctx, span := edgex.tracer.Start(req.Context(), op.name,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	return req.WithContext(ctx), span
+}
+
+// endOperationSpan - record operation outcome, span is ended with response body
+func (edgex *Edgex) endOperationSpan(span trace.Span, op *operation, res *http.Response, err error) {
+	if span == nil {
+		return
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.End()
+		return
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", res.StatusCode))
+	if sid := res.Header.Get("X-Session-Id"); sid != "" && sid != op.session {
+		span.SetAttributes(attribute.String("s3x.new_session_id", sid))
+	}
+	if res.StatusCode >= 400 {
+		span.SetStatus(codes.Error, res.Status)
+	}
+	res.Body = &tracedBody{ReadCloser: res.Body, span: span}
+}
+
+// startAttemptSpan - span of single HTTP attempt, trace context is injected into request headers
+func (edgex *Edgex) startAttemptSpan(req *http.Request, node *endpoint) trace.Span {
+	if edgex.tracer == nil {
+		return nil
+	}
+
+	ctx, span := edgex.tracer.Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", node.url.Host),
+			attribute.String("url.path", req.URL.Path),
+		))
+
+	propagator := edgex.propagator
+	if propagator == nil {
+		propagator = propagation.TraceContext{}
+	}
+	propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return span
+}
+
+func (edgex *Edgex) endAttemptSpan(span trace.Span, res *http.Response, err error) {
+	if span == nil {
+		return
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetAttributes(attribute.Int("http.response.status_code", res.StatusCode))
+		if res.StatusCode >= 400 {
+			span.SetStatus(codes.Error, res.Status)
+		}
+	}
+	span.End()
+}
+
+// tracedBody - response body ending operation span on close
+type tracedBody struct {
+	io.ReadCloser
+	span trace.Span
+	read int64
+}
+
+func (body *tracedBody) Read(p []byte) (int, error) {
+	n, err := body.ReadCloser.Read(p)
+	body.read += int64(n)
+	return n, err
+}
+
+func (body *tracedBody) Close() error {
+	err := body.ReadCloser.Close()
+	if body.span != nil {
+		body.span.SetAttributes(attribute.Int64("s3x.bytes_received", body.read))
+		body.span.End()
+		body.span = nil
+	}
+	return err
+}
+
+// kvKeyCount - number of keys updated by k/v request
+func kvKeyCount(key string, body []byte, contentType string) int {
+	if key != "" {
+		return 1
+	}
+	switch contentType {
+	case "application/json":
+		var values map[string]json.RawMessage
+		if json.Unmarshal(body, &values) == nil {
+			return len(values)
+		}
+	case "text/csv":
+		count := 0
+		for _, line := range bytes.Split(body, []byte("\n")) {
+			if len(bytes.TrimSpace(line)) > 0 {
+				count++
+			}
+		}
+		return count
+	}
+	return 0
+}
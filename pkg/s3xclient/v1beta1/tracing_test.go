@@ -0,0 +1,64 @@
+package v1beta1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func spanAttr(span sdktrace.ReadOnlySpan, key string) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if string(kv.Key) == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func Test_Tracing(t *testing.T) {
+	var lock sync.Mutex
+	var traceparents []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		traceparents = append(traceparents, r.Header.Get("traceparent"))
+		lock.Unlock()
+		w.Header().Set("X-Session-Id", "sid-1")
+	}))
+	defer srv.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	client, err := CreateEdgex(srv.URL, "", "", 0, SetTracerProvider(provider))
+	assert.Nil(t, err)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "ingest")
+	err = client.KeyValueMapPostCtx(ctx, "bk", "obj",
+		s3xApi.S3xKVMap{"k1": "v1", "k2": "v2", "k3": "v3"}, false)
+	assert.Nil(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Equal(t, 3, len(spans))
+	attempt, operation := spans[0], spans[1]
+	assert.Equal(t, "HTTP POST", attempt.Name())
+	assert.Equal(t, "KeyValueMapPost", operation.Name())
+
+	// attempt -> operation -> caller span
+	assert.Equal(t, operation.SpanContext().SpanID(), attempt.Parent().SpanID())
+	assert.Equal(t, parent.SpanContext().SpanID(), operation.Parent().SpanID())
+	assert.Equal(t, "bk", spanAttr(operation, "s3x.bucket").AsString())
+	assert.Equal(t, int64(3), spanAttr(operation, "s3x.key_count").AsInt64())
+	assert.Equal(t, "sid-1", spanAttr(operation, "s3x.new_session_id").AsString())
+
+	// gateway receives trace context of attempt span
+	assert.Equal(t, 1, len(traceparents))
+	assert.Contains(t, traceparents[0], attempt.SpanContext().SpanID().String())
+}
@@ -0,0 +1,314 @@
+package v1beta1
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"sync"
+	"time"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+	"github.com/highpeakdata/edgex-go-connector/pkg/utils"
+)
+
+const (
+	defaultTransferPartSize    = 8 * 1024 * 1024
+	defaultTransferConcurrency = 4
+	defaultTransferPartRetries = 2
+)
+
+// transferOptions - opts with defaults applied and part size aligned to chunkSize
+func transferOptions(opts s3xApi.TransferOptions, chunkSize int) s3xApi.TransferOptions {
+	if opts.PartSize <= 0 {
+		opts.PartSize = defaultTransferPartSize
+	}
+	if chunkSize > 0 && opts.PartSize%chunkSize != 0 {
+		opts.PartSize = (opts.PartSize/chunkSize + 1) * chunkSize
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultTransferConcurrency
+	}
+	if opts.PartRetries < 0 {
+		opts.PartRetries = 0
+	} else if opts.PartRetries == 0 {
+		opts.PartRetries = defaultTransferPartRetries
+	}
+	if opts.Hash == nil {
+		opts.Hash = sha256.New
+	}
+	return opts
+}
+
+// verifyChecksum - compare hasher sum with expected hex checksum
+func verifyChecksum(h hash.Hash, expected, bucket, object string) error {
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != expected {
+		return fmt.Errorf("%s/%s checksum %s, expected %s: %w", bucket, object, sum, expected, s3xErrors.ErrChecksum)
+	}
+	return nil
+}
+
+// transferProgress - serializes progress callback of parallel parts
+type transferProgress struct {
+	lock   sync.Mutex
+	done   int64
+	total  int64
+	report func(transferred, total int64)
+}
+
+func (progress *transferProgress) add(n int) {
+	if progress.report == nil {
+		return
+	}
+	progress.lock.Lock()
+	defer progress.lock.Unlock()
+	progress.done += int64(n)
+	progress.report(progress.done, progress.total)
+}
+
+// runParts - call part(i) for i in [0, n) with at most concurrency calls in flight.
+// First error cancels ctx of the parts and is returned
+func runParts(ctx context.Context, cancel context.CancelFunc, n, concurrency int, part func(i int) error) error {
+	var errOnce sync.Once
+	var firstErr error
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+dispatch:
+	for i := 0; i < n; i++ {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			break dispatch
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			if err := part(i); err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// retryPart - run part transfer up to 1+retries times while ctx is alive and its errors are transient
+func (edgex *Edgex) retryPart(ctx context.Context, op *operation, retries int, part func() error) error {
+	var err error
+	for attempt := 1; attempt <= retries+1; attempt++ {
+		if err = part(); err == nil || ctx.Err() != nil || !edgex.retryPolicy.partRetryable(err) {
+			return err
+		}
+		if attempt <= retries {
+			delay := edgex.retryPolicy.backoff(attempt, nil)
+			edgex.logger.Warn("s3x part retry", op.logArgs("attempt", attempt, "delay", delay, "error", err)...)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+	}
+	return err
+}
+
+// ObjectDownload - parallel ranged download of stream object into dst
+func (edgex *Edgex) ObjectDownload(bucket, object string, dst io.WriterAt, opts s3xApi.TransferOptions) (int64, error) {
+	return edgex.ObjectDownloadCtx(context.Background(), bucket, object, dst, opts)
+}
+
+// ObjectDownloadCtx - parallel ranged download of stream object into dst within ctx.
+// Parts are fetched concurrently and written to dst at their offsets, checksum is verified if given
+func (edgex *Edgex) ObjectDownloadCtx(ctx context.Context, bucket, object string, dst io.WriterAt, opts s3xApi.TransferOptions) (int64, error) {
+	objectPath, err := utils.GetObjectPath(bucket, object)
+	if err != nil {
+		return 0, err
+	}
+	size, chunkSize, err := edgex.streamHead(ctx, "ObjectDownload", bucket, object, objectPath, "")
+	if err != nil {
+		return 0, err
+	}
+	opts = transferOptions(opts, chunkSize)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	reader := &s3xObjectStream{edgex: edgex, ctx: ctx, bucket: bucket, object: object, path: objectPath}
+	op := &operation{name: "ObjectDownload", bucket: bucket, object: object}
+	progress := &transferProgress{total: int64(size), report: opts.Progress}
+
+	parts := (size + opts.PartSize - 1) / opts.PartSize
+	// downloaded parts, consumed in order when checksum is verified
+	results := make([]chan []byte, parts)
+	for i := range results {
+		results[i] = make(chan []byte, 1)
+	}
+
+	var errOnce sync.Once
+	var firstErr error
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	// slots bound parts in flight, and parts waiting for checksum
+	slots := make(chan struct{}, opts.Concurrency)
+	checksum := make(chan error, 1)
+	if opts.Checksum != "" {
+		go func() {
+			h := opts.Hash()
+			for i := 0; i < parts; i++ {
+				select {
+				case buf := <-results[i]:
+					h.Write(buf)
+					<-slots
+				case <-ctx.Done():
+					checksum <- ctx.Err()
+					return
+				}
+			}
+			checksum <- verifyChecksum(h, opts.Checksum, bucket, object)
+		}()
+	}
+
+	var wg sync.WaitGroup
+dispatch:
+	for i := 0; i < parts; i++ {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			break dispatch
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			offset := i * opts.PartSize
+			length := opts.PartSize
+			if offset+length > size {
+				length = size - offset
+			}
+			buf := make([]byte, length)
+			err := edgex.retryPart(ctx, op, opts.PartRetries, func() error {
+				n, err := reader.readAt(ctx, buf, offset)
+				if err == nil && n != len(buf) {
+					err = fmt.Errorf("part at %d: %d of %d bytes read: %w", offset, n, len(buf), io.ErrUnexpectedEOF)
+				}
+				return err
+			})
+			if err == nil {
+				_, err = dst.WriteAt(buf, int64(offset))
+			}
+			if err != nil {
+				fail(err)
+				return
+			}
+			progress.add(len(buf))
+			if opts.Checksum == "" {
+				<-slots
+				return
+			}
+			results[i] <- buf
+		}(i)
+	}
+	wg.Wait()
+
+	if opts.Checksum != "" {
+		if err := <-checksum; err != nil {
+			fail(err)
+		}
+	}
+	if firstErr != nil {
+		return 0, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return int64(size), nil
+}
+
+// ObjectUpload - parallel upload of size bytes of src into new stream object
+func (edgex *Edgex) ObjectUpload(bucket, object string, src io.ReaderAt, size int64, opts s3xApi.TransferOptions) error {
+	return edgex.ObjectUploadCtx(context.Background(), bucket, object, src, size, opts)
+}
+
+// ObjectUploadCtx - parallel upload of size bytes of src into new stream object within ctx.
+// Object is (re)created, chunk aligned parts are written concurrently within one stream session
+// and finalized once all of them are written. On failure or cancellation the session is cancelled
+func (edgex *Edgex) ObjectUploadCtx(ctx context.Context, bucket, object string, src io.ReaderAt, size int64, opts s3xApi.TransferOptions) error {
+	objectPath, err := utils.GetObjectPath(bucket, object)
+	if err != nil {
+		return err
+	}
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = s3xApi.DEFAULT_CHUNKSIZE
+	}
+	if opts.ContentType == "" {
+		opts.ContentType = "application/octet-stream"
+	}
+	opts = transferOptions(opts, opts.ChunkSize)
+
+	err = edgex.ObjectCreateWithMetadataCtx(ctx, bucket, object, s3xApi.OBJECT_TYPE_OBJECT, opts.ContentType,
+		opts.ChunkSize, s3xApi.DEFAULT_BTREE_ORDER, opts.Metadata)
+	if err != nil || size == 0 {
+		return err
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	writer := &s3xObjectStream{edgex: edgex, ctx: ctx, bucket: bucket, object: object, path: objectPath}
+	op := &operation{name: "ObjectUpload", bucket: bucket, object: object}
+	progress := &transferProgress{total: size, report: opts.Progress}
+	partSize := int64(opts.PartSize)
+	parts := int((size + partSize - 1) / partSize)
+
+	upload := func(i int) error {
+		offset := int64(i) * partSize
+		length := partSize
+		if offset+length > size {
+			length = size - offset
+		}
+		buf := make([]byte, length)
+		n, err := src.ReadAt(buf, offset)
+		if err != nil && !(err == io.EOF && int64(n) == length) {
+			return fmt.Errorf("%s/%s source read at %d: %w", bucket, object, offset, err)
+		}
+		err = edgex.retryPart(ctx, op, opts.PartRetries, func() error {
+			return writer.writeAt(buf, int(offset))
+		})
+		if err == nil {
+			progress.add(len(buf))
+		}
+		return err
+	}
+
+	// parts join stream session opened by the first one written
+	err = runParts(ctx, cancel, parts, opts.Concurrency, upload)
+	if err != nil {
+		if sid := writer.sessionID(); sid != "" {
+			// ctx may be cancelled already
+			edgex.finishSession(context.Background(), "ObjectUpload", bucket, object, sid, S3XURLOptions{
+				"cancel": "1",
+			})
+		}
+		if parent.Err() != nil {
+			return parent.Err()
+		}
+		return err
+	}
+	return edgex.finishSession(ctx, "ObjectUpload", bucket, object, writer.sessionID(), S3XURLOptions{
+		"finalize": "",
+	})
+}
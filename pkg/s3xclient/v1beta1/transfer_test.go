@@ -0,0 +1,193 @@
+package v1beta1
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ObjectDownload(t *testing.T) {
+	content := make([]byte, 1000000)
+	rand.New(rand.NewSource(2)).Read(content)
+	sum := sha256.Sum256(content)
+
+	server := newStreamServer()
+	server.objects["/bk/obj"] = content
+	// every 5th GET fails to exercise part retries
+	var gets int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && atomic.AddInt32(&gets, 1)%5 == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		server.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	client, err := CreateEdgex(srv.URL, "", "", 0)
+	assert.Nil(t, err)
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "obj"))
+	assert.Nil(t, err)
+	defer f.Close()
+
+	n, err := client.ObjectDownload("bk", "obj", f, s3xApi.TransferOptions{
+		PartSize:    60000,
+		Concurrency: 3,
+		Checksum:    hex.EncodeToString(sum[:]),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(len(content)), n)
+	data, err := os.ReadFile(f.Name())
+	assert.Nil(t, err)
+	assert.Equal(t, content, data)
+
+	_, err = client.ObjectDownload("bk", "obj", f, s3xApi.TransferOptions{Checksum: "00"})
+	assert.True(t, errors.Is(err, s3xErrors.ErrChecksum), "unexpected error: %v", err)
+}
+
+func Test_ObjectDownloadShortBody(t *testing.T) {
+	server := newStreamServer()
+	server.objects["/bk/obj"] = bytes.Repeat([]byte("x"), 10000)
+	// GET bodies lose their second half
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, r)
+			body := rec.Body.Bytes()
+			w.Write(body[:len(body)/2])
+			return
+		}
+		server.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	client, err := CreateEdgex(srv.URL, "", "", 0)
+	assert.Nil(t, err)
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "obj"))
+	assert.Nil(t, err)
+	defer f.Close()
+	n, err := client.ObjectDownload("bk", "obj", f, s3xApi.TransferOptions{PartSize: 4096})
+	assert.True(t, errors.Is(err, io.ErrUnexpectedEOF), "unexpected error: %v", err)
+	assert.Equal(t, int64(0), n)
+}
+
+func Test_ObjectDownloadPermanentError(t *testing.T) {
+	server := newStreamServer()
+	server.objects["/bk/obj"] = bytes.Repeat([]byte("x"), 10000)
+	// denied parts fail the same way on every attempt
+	var gets int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			atomic.AddInt32(&gets, 1)
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("<Error><Code>AccessDenied</Code></Error>"))
+			return
+		}
+		server.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	client, err := CreateEdgex(srv.URL, "", "", 0)
+	assert.Nil(t, err)
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "obj"))
+	assert.Nil(t, err)
+	defer f.Close()
+	_, err = client.ObjectDownload("bk", "obj", f, s3xApi.TransferOptions{Concurrency: 1})
+	var apiErr *s3xErrors.APIError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, "AccessDenied", apiErr.Code)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&gets))
+}
+
+func Test_ObjectUpload(t *testing.T) {
+	content := make([]byte, 100000)
+	rand.New(rand.NewSource(3)).Read(content)
+
+	server := newStreamServer()
+	server.objects["/bk/obj"] = []byte("previous version")
+	// metadata is sent with object create request
+	var camera string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Query().Has("finalize") {
+			camera = r.Header.Get("x-amz-meta-camera")
+		}
+		server.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	client, err := CreateEdgex(srv.URL, "", "", 0)
+	assert.Nil(t, err)
+
+	var lock sync.Mutex
+	var reports []int64
+	err = client.ObjectUpload("bk", "obj", bytes.NewReader(content), int64(len(content)), s3xApi.TransferOptions{
+		PartSize:    10000,
+		Concurrency: 3,
+		Metadata:    map[string]string{"camera": "cam-1"},
+		Progress: func(transferred, total int64) {
+			lock.Lock()
+			defer lock.Unlock()
+			assert.Equal(t, int64(len(content)), total)
+			reports = append(reports, transferred)
+		},
+	})
+	assert.Nil(t, err)
+
+	// 12288 bytes parts aligned to chunk size, written in one session finalized once
+	assert.Equal(t, content, server.objects["/bk/obj"])
+	assert.Equal(t, 9, server.writes)
+	assert.Equal(t, 1, server.next)
+	assert.Empty(t, server.sessions)
+	assert.Len(t, reports, 9)
+	assert.Equal(t, int64(len(content)), reports[len(reports)-1])
+	assert.Equal(t, "cam-1", camera)
+}
+
+func Test_ObjectUploadCancel(t *testing.T) {
+	content := make([]byte, 100000)
+	server := newStreamServer()
+	ctx, cancel := context.WithCancel(context.Background())
+	// cancel upload once first parts are written
+	var posts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.Header.Get("x-session-id") != "" && atomic.AddInt32(&posts, 1) == 2 {
+			cancel()
+		}
+		server.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	client, err := CreateEdgex(srv.URL, "", "", 0)
+	assert.Nil(t, err)
+
+	err = client.ObjectUploadCtx(ctx, "bk", "obj", bytes.NewReader(content), int64(len(content)), s3xApi.TransferOptions{
+		PartSize:    4096,
+		Concurrency: 2,
+	})
+	assert.True(t, errors.Is(err, context.Canceled), "unexpected error: %v", err)
+
+	// partial writes are not committed
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	assert.Empty(t, server.objects["/bk/obj"])
+	assert.Empty(t, server.sessions)
+}
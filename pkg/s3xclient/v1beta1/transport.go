@@ -0,0 +1,241 @@
+package v1beta1
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+const (
+	defaultRequestTimeout      = 45 * time.Second
+	defaultDialTimeout         = 30 * time.Second
+	defaultKeepAlive           = 30 * time.Second
+	defaultTLSHandshakeTimeout = 10 * time.Second
+	defaultIdleConnTimeout     = 90 * time.Second
+	defaultMaxIdleConns        = 256
+	defaultMaxIdleConnsPerHost = 64
+)
+
+// transportConfig - settings of the http transport shared by all Edgex operations
+type transportConfig struct {
+	requestTimeout        time.Duration
+	dialTimeout           time.Duration
+	keepAlive             time.Duration
+	tlsHandshakeTimeout   time.Duration
+	responseHeaderTimeout time.Duration
+	idleConnTimeout       time.Duration
+	maxIdleConns          int
+	maxIdleConnsPerHost   int
+	http2                 bool
+
+	// TLS settings, used for https endpoints
+	rootCAs            *x509.CertPool
+	caCertFile         string
+	clientCerts        []tls.Certificate
+	clientCertFile     string
+	clientKeyFile      string
+	serverName         string
+	insecureSkipVerify bool
+}
+
+func defaultTransportConfig() transportConfig {
+	return transportConfig{
+		requestTimeout:      defaultRequestTimeout,
+		dialTimeout:         defaultDialTimeout,
+		keepAlive:           defaultKeepAlive,
+		tlsHandshakeTimeout: defaultTLSHandshakeTimeout,
+		idleConnTimeout:     defaultIdleConnTimeout,
+		maxIdleConns:        defaultMaxIdleConns,
+		maxIdleConnsPerHost: defaultMaxIdleConnsPerHost,
+		http2:               true,
+	}
+}
+
+// tlsConfig - TLS client config, certificate files are loaded here
+func (config *transportConfig) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		RootCAs:            config.rootCAs,
+		Certificates:       config.clientCerts,
+		ServerName:         config.serverName,
+		InsecureSkipVerify: config.insecureSkipVerify,
+	}
+
+	if config.caCertFile != "" {
+		pem, err := os.ReadFile(config.caCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("CA certificate %s read error: %w", config.caCertFile, err)
+		}
+		// caller's pool is left as is
+		if tlsConfig.RootCAs == nil {
+			tlsConfig.RootCAs = x509.NewCertPool()
+		} else {
+			tlsConfig.RootCAs = tlsConfig.RootCAs.Clone()
+		}
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA certificate %s has no PEM certificates", config.caCertFile)
+		}
+	}
+
+	if config.clientCertFile != "" || config.clientKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.clientCertFile, config.clientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("client certificate %s/%s load error: %w", config.clientCertFile, config.clientKeyFile, err)
+		}
+		tlsConfig.Certificates = append(tlsConfig.Certificates, cert)
+	}
+	return tlsConfig, nil
+}
+
+// newHTTPClient - client with pooled transport built from config
+func (config *transportConfig) newHTTPClient() (*http.Client, error) {
+	tlsConfig, err := config.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   config.dialTimeout,
+		KeepAlive: config.keepAlive,
+	}
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     config.http2,
+		TLSClientConfig:       tlsConfig,
+		TLSHandshakeTimeout:   config.tlsHandshakeTimeout,
+		ResponseHeaderTimeout: config.responseHeaderTimeout,
+		IdleConnTimeout:       config.idleConnTimeout,
+		MaxIdleConns:          config.maxIdleConns,
+		MaxIdleConnsPerHost:   config.maxIdleConnsPerHost,
+		DisableKeepAlives:     config.keepAlive < 0,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	if !config.http2 {
+		// non-nil empty map disables HTTP/2 upgrade on TLS connections
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+	return &http.Client{
+		Timeout:   config.requestTimeout,
+		Transport: transport,
+	}, nil
+}
+
+// SetRequestTimeout - overall time limit of single request, including reading response body.
+// Default is 45s, 0 means no limit
+func SetRequestTimeout(timeout time.Duration) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.transport.requestTimeout = timeout
+	}
+}
+
+// SetDialTimeout - connection establishment time limit
+func SetDialTimeout(timeout time.Duration) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.transport.dialTimeout = timeout
+	}
+}
+
+// SetKeepAlive - TCP keep-alive period, negative value disables keep-alive and connection reuse
+func SetKeepAlive(keepAlive time.Duration) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.transport.keepAlive = keepAlive
+	}
+}
+
+// SetTLSHandshakeTimeout - TLS handshake time limit
+func SetTLSHandshakeTimeout(timeout time.Duration) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.transport.tlsHandshakeTimeout = timeout
+	}
+}
+
+// SetResponseHeaderTimeout - time limit to wait for response headers after request is written
+func SetResponseHeaderTimeout(timeout time.Duration) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.transport.responseHeaderTimeout = timeout
+	}
+}
+
+// SetIdleConnTimeout - how long idle pooled connection is kept open
+func SetIdleConnTimeout(timeout time.Duration) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.transport.idleConnTimeout = timeout
+	}
+}
+
+// SetMaxIdleConns - maximum number of idle pooled connections
+func SetMaxIdleConns(n int) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.transport.maxIdleConns = n
+	}
+}
+
+// SetMaxIdleConnsPerHost - maximum number of idle pooled connections per S3X host
+func SetMaxIdleConnsPerHost(n int) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.transport.maxIdleConnsPerHost = n
+	}
+}
+
+// SetHTTP2 - enable/disable HTTP/2 on TLS connections. Enabled by default
+func SetHTTP2(enabled bool) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.transport.http2 = enabled
+	}
+}
+
+// SetRootCAs - verify server certificate against pool instead of system roots
+func SetRootCAs(pool *x509.CertPool) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.transport.rootCAs = pool
+	}
+}
+
+// SetCACertFile - verify server certificate against PEM bundle file.
+// Combined with SetRootCAs pool if both are set
+func SetCACertFile(path string) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.transport.caCertFile = path
+	}
+}
+
+// SetClientCertificate - present certificate for mutual TLS
+func SetClientCertificate(cert tls.Certificate) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.transport.clientCerts = append(edgex.transport.clientCerts, cert)
+	}
+}
+
+// SetClientCertFiles - present PEM certificate/key pair loaded from files for mutual TLS
+func SetClientCertFiles(certFile, keyFile string) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.transport.clientCertFile = certFile
+		edgex.transport.clientKeyFile = keyFile
+	}
+}
+
+// SetTLSServerName - server name used for SNI and certificate verification
+// instead of endpoint host
+func SetTLSServerName(name string) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.transport.serverName = name
+	}
+}
+
+// SetInsecureSkipVerify - accept any server certificate. For test setups only
+func SetInsecureSkipVerify(insecure bool) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.transport.insecureSkipVerify = insecure
+	}
+}
+
+// Close - stop endpoint health checks and release idle pooled connections
+func (edgex *Edgex) Close() error {
+	edgex.endpoints.stopOnce.Do(func() { close(edgex.endpoints.stop) })
+	edgex.httpClient.CloseIdleConnections()
+	return nil
+}
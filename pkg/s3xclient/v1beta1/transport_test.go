@@ -0,0 +1,78 @@
+package v1beta1
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SharedTransport(t *testing.T) {
+	var conns int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.Config.ConnState = func(c net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	client, err := CreateEdgex(srv.URL, "", "", 0,
+		SetMaxIdleConnsPerHost(4),
+		SetResponseHeaderTimeout(time.Second))
+	assert.Nil(t, err)
+
+	edgex := client.(*Edgex)
+	transport := edgex.httpClient.Transport.(*http.Transport)
+	assert.Equal(t, 4, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, time.Second, transport.ResponseHeaderTimeout)
+
+	// sequential operations reuse single pooled connection
+	for i := 0; i < 5; i++ {
+		assert.Nil(t, client.BucketHead("bk"))
+		assert.Nil(t, client.ObjectHead("bk", "obj"))
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&conns))
+	assert.Nil(t, client.Close())
+}
+
+func Test_TLSOptions(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	// server certificate is not trusted by default
+	client, err := CreateEdgex(srv.URL, "", "", 0)
+	assert.Nil(t, err)
+	assert.NotNil(t, client.BucketHead("bk"))
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+	client, err = CreateEdgex(srv.URL, "", "", 0, SetRootCAs(pool))
+	assert.Nil(t, err)
+	assert.Nil(t, client.BucketHead("bk"))
+
+	client, err = CreateEdgex(srv.URL, "", "", 0, SetInsecureSkipVerify(true))
+	assert.Nil(t, err)
+	assert.Nil(t, client.BucketHead("bk"))
+
+	_, err = CreateEdgex(srv.URL, "", "", 0, SetCACertFile("/nonexistent/ca.pem"))
+	assert.NotNil(t, err)
+
+	// CA file is added to a copy of the caller's pool
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	assert.Nil(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0600))
+	pool = x509.NewCertPool()
+	client, err = CreateEdgex(srv.URL, "", "", 0, SetRootCAs(pool), SetCACertFile(caFile))
+	assert.Nil(t, err)
+	assert.Nil(t, client.BucketHead("bk"))
+	assert.True(t, pool.Equal(x509.NewCertPool()))
+}
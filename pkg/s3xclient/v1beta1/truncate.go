@@ -0,0 +1,56 @@
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+)
+
+// Truncate - set object logical size within stream session. Data past size is dropped,
+// growing object leaves a hole read as zeros. Stream offset is not changed
+func (s *s3xObjectStream) Truncate(size int64) error {
+	if err := s.checkOpen(); err != nil {
+		return err
+	}
+	if s.mode != s3xApi.SS_RANDWR {
+		return s.modeError("Truncate")
+	}
+	if size < 0 {
+		return fmt.Errorf("Invalid size %v", size)
+	}
+	if err := s.Flush(); err != nil {
+		return err
+	}
+	if err := s.post("StreamTruncate", nil, int(size), int(size)); err != nil {
+		return err
+	}
+	if s.readAhead != nil {
+		s.readAhead.reset()
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.size = int(size)
+	s.reported = int(size)
+	s.dirty = true
+	return nil
+}
+
+// ObjectTruncate - set stream object logical size, persisting new object version
+func (edgex *Edgex) ObjectTruncate(bucket, object string, size int64) error {
+	return edgex.ObjectTruncateCtx(context.Background(), bucket, object, size)
+}
+
+// ObjectTruncateCtx - set stream object logical size within ctx
+func (edgex *Edgex) ObjectTruncateCtx(ctx context.Context, bucket, object string, size int64) error {
+	stream, err := edgex.ObjectGetStreamCtx(ctx, bucket, object)
+	if err != nil {
+		return err
+	}
+	if err := stream.Truncate(size); err != nil {
+		stream.Abort()
+		return err
+	}
+	return stream.Close()
+}
@@ -0,0 +1,63 @@
+package v1beta1
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StreamTruncate(t *testing.T) {
+	server := newStreamServer()
+	server.objects["/bk/obj"] = []byte("0123456789")
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	client, err := CreateEdgex(srv.URL, "", "", 0, SetWriteBehind(64))
+	assert.Nil(t, err)
+
+	stream, err := client.ObjectGetStream("bk", "obj")
+	assert.Nil(t, err)
+	_, err = stream.Seek(8, io.SeekStart)
+	assert.Nil(t, err)
+
+	// buffered write is flushed before truncate, offset stays put
+	_, err = stream.Write([]byte("ab"))
+	assert.Nil(t, err)
+	assert.NotNil(t, stream.Truncate(-1))
+	assert.Nil(t, stream.Truncate(4))
+	assert.Equal(t, int64(4), stream.Size())
+	pos, err := stream.Seek(0, io.SeekCurrent)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), pos)
+
+	// reads past logical size hit EOF
+	buf := make([]byte, 4)
+	_, err = stream.Read(buf)
+	assert.Equal(t, io.EOF, err)
+
+	// growing object and writing past its end leave holes read as zeros
+	assert.Nil(t, stream.Truncate(6))
+	_, err = stream.Write([]byte("xy"))
+	assert.Nil(t, err)
+	_, err = stream.Seek(0, io.SeekStart)
+	assert.Nil(t, err)
+	data, err := io.ReadAll(stream)
+	assert.Nil(t, err)
+	assert.Equal(t, "0123\x00\x00\x00\x00\x00\x00xy", string(data))
+	assert.Nil(t, stream.Close())
+	assert.Equal(t, "0123\x00\x00\x00\x00\x00\x00xy", string(server.objects["/bk/obj"]))
+
+	assert.Nil(t, client.ObjectTruncate("bk", "obj", 2))
+	assert.Equal(t, "01", string(server.objects["/bk/obj"]))
+
+	stream, err = client.ObjectGetStream("bk", "obj", s3xApi.SS_APPEND)
+	assert.Nil(t, err)
+	err = stream.Truncate(0)
+	assert.True(t, errors.Is(err, s3xErrors.ErrStreamMode), "unexpected error: %v", err)
+	assert.Nil(t, stream.Close())
+}
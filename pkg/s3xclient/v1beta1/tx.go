@@ -2,16 +2,45 @@ package v1beta1
 
 import (
 	"bytes"
-	"fmt"
+	"context"
+	"encoding/json"
 	"net/http"
 	"strconv"
+	"sync"
 
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
 	"github.com/highpeakdata/edgex-go-connector/pkg/utils"
 )
 
-// KeyValueCommit - commit key/value insert/update/delete
-func (edgex *Edgex) KeyValueCommit(bucket string, object string) error {
+// kvSessions - implicit k/v session ids per bucket/object,
+// used by KeyValue* methods called with more=true
+type kvSessions struct {
+	lock sync.Mutex
+	ids  map[string]string
+}
+
+func (s *kvSessions) get(bucket, object string) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.ids[bucket+"/"+object]
+}
+
+func (s *kvSessions) set(bucket, object, sid string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if sid == "" {
+		delete(s.ids, bucket+"/"+object)
+		return
+	}
+	if s.ids == nil {
+		s.ids = make(map[string]string)
+	}
+	s.ids[bucket+"/"+object] = sid
+}
 
+// keyValueFinish - commit or cancel k/v session sid
+func (edgex *Edgex) keyValueFinish(ctx context.Context, op, bucket, object, sid string, options S3XURLOptions) error {
 	objectPath, err := utils.GetObjectPath(bucket, object)
 	if err != nil {
 		return err
@@ -21,99 +50,183 @@ func (edgex *Edgex) KeyValueCommit(bucket string, object string) error {
 	s3xurl.AddOptions(S3XURLOptions{
 		"comp":              "kv",
 		"x-ccow-autocommit": "0",
-		"finalize":          "",
 	})
+	s3xurl.AddOptions(options)
 
 	kvjson := "{}"
 
-	client := &http.Client{}
-	req, err := http.NewRequest("POST", s3xurl.String(), bytes.NewBufferString(kvjson))
+	req, err := http.NewRequestWithContext(ctx, "POST", s3xurl.String(), bytes.NewBufferString(kvjson))
 	if err != nil {
-		fmt.Printf("k/v create key/value commit post error: %v\n", err)
 		return err
 	}
 
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Content-Length", strconv.Itoa(len(kvjson)))
-	if edgex.Sid != "" {
-		req.Header.Add("x-session-id", edgex.Sid)
+	if sid != "" {
+		req.Header.Add("x-session-id", sid)
 	}
-	res, err := client.Do(req)
+	res, err := edgex.do(req, &operation{name: op, bucket: bucket, object: object, session: sid})
 	if err != nil {
-		fmt.Printf("k/v commit post error: %v\n", err)
 		return err
 	}
 	defer res.Body.Close()
 
-	fmt.Printf("k/v commit post result %v\n", res)
-
-	edgex.Sid = ""
 	if res.StatusCode < 300 {
 		return nil
 	}
-	return fmt.Errorf("%s/%s commit post status code: %v", bucket, object, res.StatusCode)
+	return s3xErrors.NewAPIError(res, op, bucket, object, "")
+}
+
+// KeyValueCommit - commit key/value insert/update/delete
+func (edgex *Edgex) KeyValueCommit(bucket string, object string) error {
+	return edgex.KeyValueCommitCtx(context.Background(), bucket, object)
+}
+
+// KeyValueCommitCtx - commit key/value insert/update/delete within ctx
+func (edgex *Edgex) KeyValueCommitCtx(ctx context.Context, bucket string, object string) error {
+	sid := edgex.sessions.get(bucket, object)
+	edgex.sessions.set(bucket, object, "")
+	return edgex.keyValueFinish(ctx, "KeyValueCommit", bucket, object, sid, S3XURLOptions{
+		"finalize": "",
+	})
 }
 
 // KeyValueRollback - rollback key/value insert/update/delete session
 func (edgex *Edgex) KeyValueRollback(bucket string, object string) error {
-	objectPath, err := utils.GetObjectPath(bucket, object)
-	if err != nil {
-		return err
-	}
+	return edgex.KeyValueRollbackCtx(context.Background(), bucket, object)
+}
 
-	s3xurl := edgex.newS3xURL(objectPath)
-	s3xurl.AddOptions(S3XURLOptions{
-		"comp":              "kv",
-		"x-ccow-autocommit": "0",
-		"cancel":            "1",
+// KeyValueRollbackCtx - rollback key/value insert/update/delete session within ctx
+func (edgex *Edgex) KeyValueRollbackCtx(ctx context.Context, bucket string, object string) error {
+	sid := edgex.sessions.get(bucket, object)
+	edgex.sessions.set(bucket, object, "")
+	return edgex.keyValueFinish(ctx, "KeyValueRollback", bucket, object, sid, S3XURLOptions{
+		"cancel": "1",
 	})
+}
 
-	kvjson := "{}"
+// s3xKVTx - explicit k/v transaction owning its session id
+type s3xKVTx struct {
+	edgex  *Edgex
+	ctx    context.Context
+	bucket string
+	object string
+
+	lock  sync.Mutex
+	sid   string
+	dirty bool
+	done  bool
+}
 
-	client := &http.Client{}
-	req, err := http.NewRequest("POST", s3xurl.String(), bytes.NewBufferString(kvjson))
-	if err != nil {
-		fmt.Printf("k/v create key/value rollback post error: %v\n", err)
-		return err
+// BeginKV - start k/v transaction on bucket/object.
+// Transactions are independent: one client may run many of them concurrently.
+func (edgex *Edgex) BeginKV(bucket, object string) (s3xApi.KVTx, error) {
+	return edgex.BeginKVCtx(context.Background(), bucket, object)
+}
+
+// BeginKVCtx - start k/v transaction bound to ctx
+func (edgex *Edgex) BeginKVCtx(ctx context.Context, bucket, object string) (s3xApi.KVTx, error) {
+	if _, err := utils.GetObjectPath(bucket, object); err != nil {
+		return nil, err
 	}
+	return &s3xKVTx{
+		edgex:  edgex,
+		ctx:    ctx,
+		bucket: bucket,
+		object: object,
+	}, nil
+}
 
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Content-Length", strconv.Itoa(len(kvjson)))
-	if edgex.Sid != "" {
-		req.Header.Add("x-session-id", edgex.Sid)
+func (tx *s3xKVTx) update(op, method, key string, body []byte, contentType string) error {
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+
+	if tx.done {
+		return s3xErrors.ErrTxDone
 	}
-	res, err := client.Do(req)
+	sid, err := tx.edgex.keyValueUpdate(tx.ctx, op, method, tx.bucket, tx.object, key, body, contentType, true, tx.sid)
 	if err != nil {
-		fmt.Printf("k/v rollback post error: %v\n", err)
 		return err
 	}
-	defer res.Body.Close()
+	if sid != "" {
+		tx.sid = sid
+	}
+	tx.dirty = true
+	return nil
+}
 
-	fmt.Printf("k/v rollback post result %v\n", res)
+func (tx *s3xKVTx) finish(op string, options S3XURLOptions) error {
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
 
-	edgex.Sid = ""
-	if res.StatusCode < 300 {
+	if tx.done {
+		return s3xErrors.ErrTxDone
+	}
+	tx.done = true
+	if !tx.dirty {
 		return nil
 	}
-	return fmt.Errorf("%s rollback post status code: %v", objectPath, res.StatusCode)
+	return tx.edgex.keyValueFinish(tx.ctx, op, tx.bucket, tx.object, tx.sid, options)
 }
 
-// Finalize - close client connection
-func (edgex *Edgex) Close(bucket, object string) error {
-	objectPath, err := utils.GetObjectPath(bucket, object)
+// SessionID - server session id, empty until first update
+func (tx *s3xKVTx) SessionID() string {
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+	return tx.sid
+}
+
+// Put - post single key/value pair
+func (tx *s3xKVTx) Put(key string, value *bytes.Buffer, contentType string) error {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return tx.update("KVTx.Put", "POST", key, value.Bytes(), contentType)
+}
+
+// Delete - delete single key
+func (tx *s3xKVTx) Delete(key string) error {
+	return tx.update("KVTx.Delete", "DELETE", key, nil, "application/octet-stream")
+}
+
+// PutMap - post key/value map in JSON format
+func (tx *s3xKVTx) PutMap(values s3xApi.S3xKVMap) error {
+	jsonBytes, err := json.Marshal(values)
 	if err != nil {
 		return err
 	}
+	return tx.update("KVTx.PutMap", "POST", "", jsonBytes, "application/json")
+}
 
-	fmt.Printf("Closing connection to %s\n", objectPath)
+// DeleteMap - delete keys of key/value map
+func (tx *s3xKVTx) DeleteMap(values s3xApi.S3xKVMap) error {
+	jsonBytes, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	return tx.update("KVTx.DeleteMap", "DELETE", "", jsonBytes, "application/json")
+}
 
-	s3xurl := edgex.newS3xURL(objectPath)
-	s3xurl.AddOptions(S3XURLOptions{
-		"comp":     "streamsession",
+// PutJSON - post key/value pairs defined by json
+func (tx *s3xKVTx) PutJSON(keyValueJSON string) error {
+	return tx.update("KVTx.PutJSON", "POST", "", []byte(keyValueJSON), "application/json")
+}
+
+// PutCSV - post key/value pairs presented like csv
+func (tx *s3xKVTx) PutCSV(keyValueCSV string) error {
+	return tx.update("KVTx.PutCSV", "POST", "", []byte(keyValueCSV), "text/csv")
+}
+
+// Commit - commit all transaction updates
+func (tx *s3xKVTx) Commit() error {
+	return tx.finish("KVTx.Commit", S3XURLOptions{
 		"finalize": "",
 	})
+}
 
-	http.Head(s3xurl.String())
-
-	return nil
+// Rollback - cancel all transaction updates
+func (tx *s3xKVTx) Rollback() error {
+	return tx.finish("KVTx.Rollback", S3XURLOptions{
+		"cancel": "1",
+	})
 }
@@ -0,0 +1,73 @@
+package v1beta1
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sessionServer assigns new session id to every k/v update without x-session-id
+// and records which session each committed key belongs to
+type sessionServer struct {
+	lock      sync.Mutex
+	next      int
+	keys      map[string]string
+	committed map[string]bool
+}
+
+func (s *sessionServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	sid := r.Header.Get("x-session-id")
+	if sid == "" {
+		s.next++
+		sid = fmt.Sprintf("sid-%d", s.next)
+	}
+	if key := r.URL.Query().Get("key"); key != "" {
+		s.keys[key] = sid
+	}
+	if _, ok := r.URL.Query()["finalize"]; ok {
+		s.committed[sid] = true
+	}
+	w.Header().Set("X-Session-Id", sid)
+}
+
+func Test_KVTxConcurrentSessions(t *testing.T) {
+	server := &sessionServer{keys: map[string]string{}, committed: map[string]bool{}}
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	client, err := CreateEdgex(srv.URL, "", "", 0)
+	assert.Nil(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tx, err := client.BeginKV("bk", fmt.Sprintf("obj%d", i))
+			assert.Nil(t, err)
+			for j := 0; j < 3; j++ {
+				assert.Nil(t, tx.Put(fmt.Sprintf("k%d-%d", i, j), bytes.NewBufferString("v"), ""))
+			}
+			assert.Nil(t, tx.Commit())
+		}(i)
+	}
+	wg.Wait()
+
+	// all keys of one transaction share its own session
+	for i := 0; i < 4; i++ {
+		sid := server.keys[fmt.Sprintf("k%d-0", i)]
+		assert.True(t, server.committed[sid])
+		for j := 1; j < 3; j++ {
+			assert.Equal(t, sid, server.keys[fmt.Sprintf("k%d-%d", i, j)])
+		}
+	}
+	assert.Equal(t, 4, len(server.committed))
+}
@@ -0,0 +1,79 @@
+package v1beta1
+
+// SetWriteBehind - coalesce contiguous stream writes into requests of at least size bytes,
+// rounded up to object chunk size. Buffer is flushed on Seek, Read, Flush and Close.
+// size 0 (default) sends every Write to the server as is
+func SetWriteBehind(size int) EdgexOption {
+	return func(edgex *Edgex) {
+		edgex.writeBehindSize = size
+	}
+}
+
+// writeBehind - pending writes of one object stream, data belongs at object offset
+type writeBehind struct {
+	size      int
+	chunkSize int
+
+	offset int
+	data   []byte
+	// first failed flush, reported by all subsequent calls
+	err error
+}
+
+func newWriteBehind(size, chunkSize int) *writeBehind {
+	if chunkSize > 0 && size%chunkSize != 0 {
+		size = (size/chunkSize + 1) * chunkSize
+	}
+	return &writeBehind{size: size, chunkSize: chunkSize}
+}
+
+// write - buffer p written at offset, sending chunk aligned head of buffer once it is full
+func (wb *writeBehind) write(s *s3xObjectStream, p []byte, offset int) error {
+	if wb.err != nil {
+		return wb.err
+	}
+	if len(wb.data) > 0 && offset != wb.offset+len(wb.data) {
+		if err := wb.flush(s); err != nil {
+			return err
+		}
+	}
+	if len(wb.data) == 0 {
+		wb.offset = offset
+	}
+	wb.data = append(wb.data, p...)
+
+	if len(wb.data) < wb.size {
+		return nil
+	}
+	// keep tail after last chunk boundary for next writes
+	n := len(wb.data)
+	if wb.chunkSize > 0 {
+		if aligned := (wb.offset+n)/wb.chunkSize*wb.chunkSize - wb.offset; aligned > 0 {
+			n = aligned
+		}
+	}
+	if err := s.writeAt(wb.data[:n], wb.offset); err != nil {
+		wb.err = err
+		return err
+	}
+	wb.offset += n
+	wb.data = append(wb.data[:0], wb.data[n:]...)
+	return nil
+}
+
+// flush - send all buffered data
+func (wb *writeBehind) flush(s *s3xObjectStream) error {
+	if wb.err != nil {
+		return wb.err
+	}
+	if len(wb.data) == 0 {
+		return nil
+	}
+	if err := s.writeAt(wb.data, wb.offset); err != nil {
+		wb.err = err
+		return err
+	}
+	wb.offset += len(wb.data)
+	wb.data = wb.data[:0]
+	return nil
+}
@@ -0,0 +1,82 @@
+package v1beta1
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WriteBehind(t *testing.T) {
+	server := newStreamServer()
+	server.objects["/bk/obj"] = nil
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	client, err := CreateEdgex(srv.URL, "", "", 0, SetWriteBehind(8000))
+	assert.Nil(t, err)
+	stream, err := client.ObjectGetStream("bk", "obj")
+	assert.Nil(t, err)
+
+	// 20 bytes records are sent in 8192 bytes chunk aligned requests
+	var expected bytes.Buffer
+	for i := 0; i < 1000; i++ {
+		record := fmt.Sprintf("record %012d\n", i)
+		expected.WriteString(record)
+		n, err := stream.Write([]byte(record))
+		assert.Nil(t, err)
+		assert.Equal(t, len(record), n)
+	}
+	assert.Equal(t, 2, server.writes)
+	assert.Nil(t, stream.Close())
+	assert.Equal(t, 3, server.writes)
+	assert.Equal(t, expected.Bytes(), server.objects["/bk/obj"])
+
+	// failed flush is reported by Close
+	server.writeStatus = http.StatusInternalServerError
+	stream, err = client.ObjectGetStream("bk", "obj")
+	assert.Nil(t, err)
+	_, err = stream.Write([]byte("lost"))
+	assert.Nil(t, err)
+	assert.NotNil(t, stream.Close())
+
+	// aborting buffered writes has no session to cancel
+	server.writeStatus = 0
+	stream, err = client.ObjectGetStream("bk", "obj")
+	assert.Nil(t, err)
+	_, err = stream.Write([]byte("dropped"))
+	assert.Nil(t, err)
+	finishes := server.finishes
+	assert.Nil(t, stream.Abort())
+	assert.Equal(t, finishes, server.finishes)
+	assert.Equal(t, expected.Bytes(), server.objects["/bk/obj"])
+}
+
+func Test_WriteBehindCloseCancelled(t *testing.T) {
+	server := newStreamServer()
+	server.objects["/bk/obj"] = []byte("0123456789")
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	client, err := CreateEdgex(srv.URL, "", "", 0, SetWriteBehind(64))
+	assert.Nil(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.ObjectGetStreamCtx(ctx, "bk", "obj")
+	assert.Nil(t, err)
+	_, err = stream.WriteAt([]byte("ab"), 0)
+	assert.Nil(t, err)
+	_, err = stream.Write([]byte("buffered"))
+	assert.Nil(t, err)
+
+	// failed flush of cancelled stream still cancels its session
+	cancel()
+	err = stream.Close()
+	assert.True(t, errors.Is(err, context.Canceled), "unexpected error: %v", err)
+	assert.Empty(t, server.sessions)
+	assert.Equal(t, "0123456789", string(server.objects["/bk/obj"]))
+}
@@ -1,6 +1,7 @@
 package bucket
 
 import (
+	"errors"
 	"fmt"
 
 	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
@@ -13,7 +14,7 @@ func BucketCreationFlow(suite suite.Suite, client s3xApi.S3xClient, bucket strin
 	// bucket not exist
 	if suite.Error(err) {
 		// should be ErrBucketNotExist error
-		suite.Equal(s3xErrors.ErrBucketNotExist, err)
+		suite.True(errors.Is(err, s3xErrors.ErrBucketNotExist), "unexpected error: %v", err)
 
 	} else {
 		// deleting existing bucket
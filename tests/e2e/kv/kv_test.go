@@ -3,6 +3,7 @@ package kv
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
@@ -11,6 +12,7 @@ import (
 	"time"
 
 	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
 	v1beta1 "github.com/highpeakdata/edgex-go-connector/pkg/s3xclient/v1beta1"
 	mock "github.com/highpeakdata/edgex-go-connector/tests/s3xMockClient"
 
@@ -99,6 +101,7 @@ func (suite *e2eKVTestSuite) TestObjectFlow() {
 	suite.PostSingleKVTest()
 	//suite.PostJSONTest()
 	suite.PostMapTest()
+	suite.TransactionTest()
 }
 
 func (suite *e2eKVTestSuite) PostSingleKVTest() {
@@ -192,3 +195,40 @@ func (suite *e2eKVTestSuite) PostMapTest() {
 	err = suite.s3x.KeyValueMapDelete(suite.Bucket, suite.Object, testMap, false)
 	suite.Nil(err)
 }
+
+func (suite *e2eKVTestSuite) TransactionTest() {
+	committed, err := suite.s3x.BeginKV(suite.Bucket, suite.Object)
+	suite.Nil(err)
+	rolledBack, err := suite.s3x.BeginKV(suite.Bucket, suite.Object)
+	suite.Nil(err)
+
+	// interleave updates of two independent transactions
+	suite.Nil(committed.Put("txKey1", bytes.NewBufferString("txValue1"), ""))
+	suite.Nil(rolledBack.Put("txKey2", bytes.NewBufferString("txValue2"), ""))
+	suite.Nil(committed.PutCSV(utils.ArrToCVS("txKey3", "txValue3")))
+
+	// nothing is visible before commit
+	_, err = suite.s3x.KeyValueGet(suite.Bucket, suite.Object, "txKey1")
+	suite.True(errors.Is(err, s3xErrors.ErrKeyNotExist), "unexpected error: %v", err)
+
+	before, err := suite.s3x.ObjectStat(suite.Bucket, suite.Object)
+	suite.Nil(err)
+	suite.Nil(rolledBack.Rollback())
+	suite.Nil(committed.Commit())
+	suite.True(errors.Is(committed.Commit(), s3xErrors.ErrTxDone))
+
+	// commit creates new object version
+	after, err := suite.s3x.ObjectStat(suite.Bucket, suite.Object)
+	suite.Nil(err)
+	suite.NotEqual(before.VersionID, after.VersionID)
+	suite.False(after.LastModified.Before(before.LastModified))
+
+	result, err := suite.s3x.KeyValueGet(suite.Bucket, suite.Object, "txKey1")
+	suite.Nil(err)
+	suite.Equal("txValue1", result)
+	result, err = suite.s3x.KeyValueGet(suite.Bucket, suite.Object, "txKey3")
+	suite.Nil(err)
+	suite.Equal("txValue3", result)
+	_, err = suite.s3x.KeyValueGet(suite.Bucket, suite.Object, "txKey2")
+	suite.NotNil(err)
+}
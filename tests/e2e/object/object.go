@@ -2,8 +2,14 @@ package object
 
 import (
 	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"strings"
 
 	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
 	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
@@ -15,7 +21,7 @@ func ObjectCreationFlow(suite suite.Suite, client s3xApi.S3xClient, bucket, obje
 	// object not exist
 	if suite.Error(err) {
 		// should be ErrObjectNotExist error
-		suite.Equal(s3xErrors.ErrObjectNotExist, err)
+		suite.True(errors.Is(err, s3xErrors.ErrObjectNotExist), "unexpected error: %v", err)
 
 	} else {
 		// deleting existing object
@@ -41,6 +47,13 @@ func ObjectCreationFlow(suite suite.Suite, client s3xApi.S3xClient, bucket, obje
 	}
 
 	suite.Equal(true, objectExists, "Object should be presented")
+
+	info, err := client.ObjectStat(bucket, object)
+	suite.Nil(err)
+	suite.Equal(s3xApi.OBJECT_TYPE_KEY_VALUE, info.ObjectType)
+	suite.Equal("application/json", info.ContentType)
+	suite.Equal(s3xApi.DEFAULT_CHUNKSIZE, info.ChunkSize)
+	suite.Equal(s3xApi.DEFAULT_BTREE_ORDER, info.BTreeOrder)
 }
 
 func ObjectDeletionFlow(suite suite.Suite, client s3xApi.S3xClient, bucket, object string) {
@@ -58,7 +71,7 @@ func ObjectDeletionFlow(suite suite.Suite, client s3xApi.S3xClient, bucket, obje
 	// object not exist
 	if suite.Error(err) {
 		// should be ErrObjectNotExist error
-		suite.Equal(s3xErrors.ErrObjectNotExist, err)
+		suite.True(errors.Is(err, s3xErrors.ErrObjectNotExist), "unexpected error: %v", err)
 	}
 }
 
@@ -108,6 +121,16 @@ func ObjectStreamFlow(suite suite.Suite, client s3xApi.S3xClient, bucket, object
 	bio.Reset(stream)
 	line1, err := bio.ReadString('\n')
 	suite.Equal(line, line1)
+	fmt.Printf("Positional read test\n")
+	size := int64(len(bufferString) + len(appendString))
+	suite.Equal(size, stream.Size())
+	n1, err = stream.Stat()
+	suite.Nil(err)
+	suite.Equal(size, n1)
+	buf := make([]byte, len(appendString))
+	n, err = stream.ReadAt(buf, int64(len(bufferString)))
+	suite.Nil(err)
+	suite.Equal(appendString, string(buf[:n]))
 	err = stream.Close()
 	suite.Nil(err)
 	fmt.Printf("Deleting object %s/%s\n", bucket, object)
@@ -115,3 +138,271 @@ func ObjectStreamFlow(suite suite.Suite, client s3xApi.S3xClient, bucket, object
 	err = client.ObjectDelete(bucket, object)
 	suite.Nil(err)
 }
+
+func ObjectPutGetFlow(suite suite.Suite, client s3xApi.S3xClient, bucket, object string) {
+	content := "0123456789abcdefghij"
+	err := client.ObjectPut(bucket, object, strings.NewReader(content), int64(len(content)), "image/png",
+		map[string]string{"camera": "cam-1"})
+	suite.Nil(err)
+
+	body, info, err := client.ObjectGet(bucket, object)
+	suite.Nil(err)
+	data, err := io.ReadAll(body)
+	suite.Nil(err)
+	body.Close()
+	suite.Equal(content, string(data))
+	suite.Equal(int64(len(content)), info.Size)
+	suite.Equal("image/png", info.ContentType)
+	suite.Equal("cam-1", info.Metadata["camera"])
+
+	body, _, err = client.ObjectGetRange(bucket, object, 5, 4)
+	suite.Nil(err)
+	data, err = io.ReadAll(body)
+	suite.Nil(err)
+	body.Close()
+	suite.Equal("5678", string(data))
+
+	_, _, err = client.ObjectGetRange(bucket, object, 100, 4)
+	suite.True(errors.Is(err, s3xErrors.ErrInvalidRange), "unexpected error: %v", err)
+
+	err = client.ObjectDelete(bucket, object)
+	suite.Nil(err)
+}
+
+func ObjectMultipartFlow(suite suite.Suite, client s3xApi.S3xClient, bucket, object string) {
+	content := strings.Repeat("0123456789", 250)
+	size, err := client.ObjectUploadMultipart(bucket, object, strings.NewReader(content), s3xApi.TransferOptions{
+		PartSize:    1000,
+		ContentType: "text/plain",
+	})
+	suite.Nil(err)
+	suite.Equal(int64(len(content)), size)
+
+	body, info, err := client.ObjectGet(bucket, object)
+	suite.Nil(err)
+	data, err := io.ReadAll(body)
+	suite.Nil(err)
+	body.Close()
+	suite.Equal(content, string(data))
+	suite.Equal("text/plain", info.ContentType)
+
+	// aborted upload leaves nothing behind
+	uploadID, err := client.MultipartInitiate(bucket, object, "text/plain", nil)
+	suite.Nil(err)
+	_, err = client.MultipartUploadPart(bucket, object, uploadID, 1, strings.NewReader("part"), 4)
+	suite.Nil(err)
+	uploads, err := client.MultipartListUploads(bucket, object)
+	suite.Nil(err)
+	suite.Len(uploads, 1)
+	suite.Nil(client.MultipartAbort(bucket, object, uploadID))
+	uploads, err = client.MultipartListUploads(bucket, object)
+	suite.Nil(err)
+	suite.Empty(uploads)
+
+	err = client.ObjectDelete(bucket, object)
+	suite.Nil(err)
+}
+
+func ObjectCopyFlow(suite suite.Suite, client s3xApi.S3xClient, bucket, object string) {
+	err := client.ObjectCreate(bucket, object, s3xApi.OBJECT_TYPE_KEY_VALUE, "application/json", s3xApi.DEFAULT_CHUNKSIZE, s3xApi.DEFAULT_BTREE_ORDER)
+	suite.Nil(err)
+	err = client.KeyValuePost(bucket, object, "key1", bytes.NewBufferString("value1"), "", false)
+	suite.Nil(err)
+
+	copied := object + "-copy"
+	err = client.ObjectCopy(bucket, object, bucket, copied, s3xApi.CopyOptions{})
+	suite.Nil(err)
+	value, err := client.KeyValueGet(bucket, copied, "key1")
+	suite.Nil(err)
+	suite.Equal("value1", value)
+
+	renamed := object + "-renamed"
+	err = client.ObjectRename(bucket, copied, bucket, renamed)
+	suite.Nil(err)
+	err = client.ObjectHead(bucket, copied)
+	suite.True(errors.Is(err, s3xErrors.ErrObjectNotExist), "unexpected error: %v", err)
+	info, err := client.ObjectStat(bucket, renamed)
+	suite.Nil(err)
+	suite.Equal(s3xApi.OBJECT_TYPE_KEY_VALUE, info.ObjectType)
+	suite.Equal("application/json", info.ContentType)
+
+	// renaming onto itself keeps the object
+	err = client.ObjectRename(bucket, renamed, bucket, renamed)
+	suite.True(errors.Is(err, s3xErrors.ErrInvalidArgument), "unexpected error: %v", err)
+	suite.Nil(client.ObjectHead(bucket, renamed))
+
+	suite.Nil(client.ObjectDelete(bucket, renamed))
+	suite.Nil(client.ObjectDelete(bucket, object))
+}
+
+func ObjectStreamModesFlow(suite suite.Suite, client s3xApi.S3xClient, bucket, object string) {
+	err := client.ObjectCreate(bucket, object, s3xApi.OBJECT_TYPE_OBJECT, "text/plain", s3xApi.DEFAULT_CHUNKSIZE, s3xApi.DEFAULT_BTREE_ORDER)
+	suite.Nil(err)
+
+	for _, line := range []string{"line1\n", "line2\n"} {
+		stream, err := client.ObjectGetStream(bucket, object, s3xApi.SS_APPEND)
+		suite.Nil(err)
+		_, err = stream.Write([]byte(line))
+		suite.Nil(err)
+		_, err = stream.WriteAt([]byte(line), 0)
+		suite.True(errors.Is(err, s3xErrors.ErrStreamMode), "unexpected error: %v", err)
+		suite.Nil(stream.Close())
+	}
+
+	stream, err := client.ObjectGetStream(bucket, object, s3xApi.SS_RDONLY)
+	suite.Nil(err)
+	_, err = stream.Write([]byte("line3\n"))
+	suite.True(errors.Is(err, s3xErrors.ErrStreamMode), "unexpected error: %v", err)
+	data, err := io.ReadAll(stream)
+	suite.Nil(err)
+	suite.Equal("line1\nline2\n", string(data))
+	suite.Nil(stream.Close())
+
+	suite.Nil(client.ObjectDelete(bucket, object))
+}
+
+func ObjectStreamAbortFlow(suite suite.Suite, client s3xApi.S3xClient, bucket, object string) {
+	err := client.ObjectCreate(bucket, object, s3xApi.OBJECT_TYPE_OBJECT, "text/plain", s3xApi.DEFAULT_CHUNKSIZE, s3xApi.DEFAULT_BTREE_ORDER)
+	suite.Nil(err)
+
+	stream, err := client.ObjectGetStream(bucket, object)
+	suite.Nil(err)
+	_, err = stream.Write([]byte("kept"))
+	suite.Nil(err)
+	suite.Nil(stream.Close())
+	// closed stream can't be used, closing it again is a no-op
+	_, err = stream.Read(make([]byte, 4))
+	suite.True(errors.Is(err, s3xErrors.ErrStreamClosed), "unexpected error: %v", err)
+	_, err = stream.Write([]byte("late"))
+	suite.True(errors.Is(err, s3xErrors.ErrStreamClosed), "unexpected error: %v", err)
+	suite.Nil(stream.Close())
+
+	// aborted writes are discarded
+	stream, err = client.ObjectGetStream(bucket, object)
+	suite.Nil(err)
+	_, err = stream.WriteAt([]byte("lost"), 0)
+	suite.Nil(err)
+	suite.Nil(stream.Abort())
+	suite.Nil(stream.Abort())
+
+	stream, err = client.ObjectGetStream(bucket, object, s3xApi.SS_RDONLY)
+	suite.Nil(err)
+	data, err := io.ReadAll(stream)
+	suite.Nil(err)
+	suite.Equal("kept", string(data))
+	suite.Nil(stream.Close())
+
+	suite.Nil(client.ObjectDelete(bucket, object))
+}
+
+func ObjectTruncateFlow(suite suite.Suite, client s3xApi.S3xClient, bucket, object string) {
+	err := client.ObjectCreate(bucket, object, s3xApi.OBJECT_TYPE_OBJECT, "application/octet-stream", s3xApi.DEFAULT_CHUNKSIZE, s3xApi.DEFAULT_BTREE_ORDER)
+	suite.Nil(err)
+
+	stream, err := client.ObjectGetStream(bucket, object)
+	suite.Nil(err)
+	_, err = stream.Write([]byte("0123456789"))
+	suite.Nil(err)
+	suite.Nil(stream.Truncate(4))
+	suite.Equal(int64(4), stream.Size())
+
+	// write past the end leaves a hole read as zeros
+	_, err = stream.Seek(6, io.SeekStart)
+	suite.Nil(err)
+	_, err = stream.Write([]byte("xy"))
+	suite.Nil(err)
+	_, err = stream.Seek(0, io.SeekStart)
+	suite.Nil(err)
+	data, err := io.ReadAll(stream)
+	suite.Nil(err)
+	suite.Equal("0123\x00\x00xy", string(data))
+	suite.Nil(stream.Close())
+
+	// aborted truncate keeps the object
+	stream, err = client.ObjectGetStream(bucket, object)
+	suite.Nil(err)
+	suite.Nil(stream.Truncate(1))
+	suite.Nil(stream.Abort())
+	info, err := client.ObjectStat(bucket, object)
+	suite.Nil(err)
+	suite.Equal(int64(8), info.Size)
+
+	suite.Nil(client.ObjectTruncate(bucket, object, 2))
+	info, err = client.ObjectStat(bucket, object)
+	suite.Nil(err)
+	suite.Equal(int64(2), info.Size)
+
+	suite.Nil(client.ObjectDelete(bucket, object))
+}
+
+func ObjectTransferFlow(suite suite.Suite, client s3xApi.S3xClient, bucket, object string) {
+	content := "transferred content"
+	meta := map[string]string{"source": "lab"}
+	err := client.ObjectUpload(bucket, object, strings.NewReader(content), int64(len(content)), s3xApi.TransferOptions{Metadata: meta})
+	suite.Nil(err)
+	values, err := client.ObjectGetMetadata(bucket, object)
+	suite.Nil(err)
+	suite.Equal(meta, values)
+
+	dst, err := os.CreateTemp(suite.T().TempDir(), "download")
+	suite.Nil(err)
+	defer dst.Close()
+	sum := sha256.Sum256([]byte(content))
+	var reports []int64
+	n, err := client.ObjectDownload(bucket, object, dst, s3xApi.TransferOptions{
+		Checksum: hex.EncodeToString(sum[:]),
+		PartSize: 8,
+		Progress: func(transferred, total int64) {
+			suite.Equal(int64(len(content)), total)
+			reports = append(reports, transferred)
+		},
+	})
+	suite.Nil(err)
+	suite.Equal(int64(len(content)), n)
+	suite.NotEmpty(reports)
+	suite.Equal(int64(len(content)), reports[len(reports)-1])
+	data, err := os.ReadFile(dst.Name())
+	suite.Nil(err)
+	suite.Equal(content, string(data))
+
+	// upload replacing the object creates its new version with new metadata
+	before, err := client.ObjectStat(bucket, object)
+	suite.Nil(err)
+	err = client.ObjectUpload(bucket, object, strings.NewReader(content), int64(len(content)), s3xApi.TransferOptions{ContentType: "text/plain"})
+	suite.Nil(err)
+	info, err := client.ObjectStat(bucket, object)
+	suite.Nil(err)
+	suite.Nil(info.Metadata)
+	suite.Equal("text/plain", info.ContentType)
+	suite.NotEqual(before.VersionID, info.VersionID)
+
+	// checksum mismatch reports nothing downloaded
+	n, err = client.ObjectDownload(bucket, object, dst, s3xApi.TransferOptions{Checksum: "00"})
+	suite.True(errors.Is(err, s3xErrors.ErrChecksum), "unexpected error: %v", err)
+	suite.Equal(int64(0), n)
+
+	suite.Nil(client.ObjectDelete(bucket, object))
+}
+
+func ObjectMetadataFlow(suite suite.Suite, client s3xApi.S3xClient, bucket, object string) {
+	meta := map[string]string{"source": "lab", "camera": "cam-1", "run": "ingest-7"}
+	err := client.ObjectCreateWithMetadata(bucket, object, s3xApi.OBJECT_TYPE_OBJECT, "image/png", s3xApi.DEFAULT_CHUNKSIZE, s3xApi.DEFAULT_BTREE_ORDER, meta)
+	suite.Nil(err)
+
+	values, err := client.ObjectGetMetadata(bucket, object)
+	suite.Nil(err)
+	suite.Equal(meta, values)
+
+	err = client.ObjectSetMetadata(bucket, object, map[string]string{"run": "ingest-8"})
+	suite.Nil(err)
+	values, err = client.ObjectGetMetadata(bucket, object)
+	suite.Nil(err)
+	suite.Equal(map[string]string{"run": "ingest-8"}, values)
+
+	// content type is kept
+	info, err := client.ObjectStat(bucket, object)
+	suite.Nil(err)
+	suite.Equal("image/png", info.ContentType)
+
+	suite.Nil(client.ObjectDelete(bucket, object))
+}
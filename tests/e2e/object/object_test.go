@@ -81,4 +81,12 @@ func (suite *e2eObjectTestSuite) TestObjectFlow() {
 	ObjectCreationFlow(suite.Suite, suite.s3x, suite.Bucket, suite.Object)
 	ObjectDeletionFlow(suite.Suite, suite.s3x, suite.Bucket, suite.Object)
 	ObjectStreamFlow(suite.Suite, suite.s3x, suite.Bucket, suite.Object)
+	ObjectPutGetFlow(suite.Suite, suite.s3x, suite.Bucket, suite.Object)
+	ObjectMultipartFlow(suite.Suite, suite.s3x, suite.Bucket, suite.Object)
+	ObjectCopyFlow(suite.Suite, suite.s3x, suite.Bucket, suite.Object)
+	ObjectStreamModesFlow(suite.Suite, suite.s3x, suite.Bucket, suite.Object)
+	ObjectStreamAbortFlow(suite.Suite, suite.s3x, suite.Bucket, suite.Object)
+	ObjectTruncateFlow(suite.Suite, suite.s3x, suite.Bucket, suite.Object)
+	ObjectTransferFlow(suite.Suite, suite.s3x, suite.Bucket, suite.Object)
+	ObjectMetadataFlow(suite.Suite, suite.s3x, suite.Bucket, suite.Object)
 }
@@ -2,11 +2,13 @@ package s3xMockClient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"sync"
@@ -26,6 +28,13 @@ type kvobj struct {
 	KeyValue  map[string]string `json:"keyValue"`
 	recent    map[string]string `json:"-"`
 	recentDel []string          `json:"-"`
+
+	ContentType string            `json:"contentType,omitempty"`
+	ChunkSize   int               `json:"chunkSize,omitempty"`
+	BTreeOrder  int               `json:"btreeOrder,omitempty"`
+	Modified    time.Time         `json:"modified"`
+	Generation  uint64            `json:"generation,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
 }
 
 // Mockup - mockup client mockup structure
@@ -34,22 +43,47 @@ type Mockup struct {
 	Buckets map[string]s3xApi.Bucket `json:"buckets"`
 	lock    sync.Mutex
 
+	// Multipart uploads in progress by upload id
+	uploads map[string]*mockupUpload
+
 	// Current session
 	Bucket string `json:"-"`
 	Object string `json:"-"`
-	Sid    string `json:"-"`
 	Debug  int    `json:"-"`
 }
 
+// MockupObjectStream - stream over staged copy of object file, renamed over object on Close
+// and removed on Abort
 type MockupObjectStream struct {
+	ctx context.Context
+	// staged copy of stream file at path
 	h      *os.File
 	path   string
 	offset int
+	// open mode, SS_RANDWR, SS_APPEND or SS_RDONLY
+	mode int
+
+	lock   sync.Mutex
 	size   int
 	dirty  bool
+	closed bool
+}
+
+// check - stream is open and its ctx is not done
+func (m *MockupObjectStream) check() error {
+	m.lock.Lock()
+	closed := m.closed
+	m.lock.Unlock()
+	if closed {
+		return fmt.Errorf("Stream %v: %w", m.path, s3xErrors.ErrStreamClosed)
+	}
+	return m.ctx.Err()
 }
 
 func (m *MockupObjectStream) Read(p []byte) (int, error) {
+	if err := m.check(); err != nil {
+		return 0, err
+	}
 	n, err := m.h.ReadAt(p, int64(m.offset))
 	if n == 0 && err != nil {
 		return 0, err
@@ -59,54 +93,157 @@ func (m *MockupObjectStream) Read(p []byte) (int, error) {
 }
 
 func (m *MockupObjectStream) Write(p []byte) (int, error) {
-	n, err := m.h.WriteAt(p, int64(m.offset))
-	if err != nil {
+	if err := m.check(); err != nil {
 		return 0, err
 	}
-	if n+m.offset > m.size {
-		m.size = n + m.offset
+	if m.mode == s3xApi.SS_RDONLY {
+		return 0, fmt.Errorf("Write %v: %w", m.path, s3xErrors.ErrStreamMode)
+	}
+	if m.mode == s3xApi.SS_APPEND {
+		m.offset = int(m.Size())
+	}
+	n, err := m.writeAt(p, int64(m.offset))
+	if err != nil {
+		return 0, err
 	}
 	m.offset += n
+	return n, nil
+}
+
+// ReadAt - read at off, stream offset is not changed
+func (m *MockupObjectStream) ReadAt(p []byte, off int64) (int, error) {
+	if err := m.check(); err != nil {
+		return 0, err
+	}
+	return m.h.ReadAt(p, off)
+}
+
+// WriteAt - write at off, stream offset is not changed
+func (m *MockupObjectStream) WriteAt(p []byte, off int64) (int, error) {
+	if err := m.check(); err != nil {
+		return 0, err
+	}
+	if m.mode != s3xApi.SS_RANDWR {
+		return 0, fmt.Errorf("WriteAt %v: %w", m.path, s3xErrors.ErrStreamMode)
+	}
+	return m.writeAt(p, off)
+}
+
+func (m *MockupObjectStream) writeAt(p []byte, off int64) (int, error) {
+	n, err := m.h.WriteAt(p, off)
+	if err != nil {
+		return 0, err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if int(off)+n > m.size {
+		m.size = int(off) + n
+	}
 	m.dirty = true
 	return n, nil
 }
 
+// Size - stream file size
+func (m *MockupObjectStream) Size() int64 {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return int64(m.size)
+}
+
+// Stat - refresh stream file size
+func (m *MockupObjectStream) Stat() (int64, error) {
+	if err := m.check(); err != nil {
+		return 0, err
+	}
+	stat, err := m.h.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("File %v stat error: %v", m.h.Name(), err)
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.size = int(stat.Size())
+	return stat.Size(), nil
+}
+
 func (s *MockupObjectStream) Seek(offset int64, whence int) (int64, error) {
-	stat, err := s.h.Stat()
+	size, err := s.Stat()
 	if err != nil {
-		return 0, fmt.Errorf("File %v stat error: %v", s.h.Name(), err)
+		return 0, err
 	}
-	s.size = int(stat.Size())
 	newPos := 0
 	if whence == io.SeekCurrent {
 		newPos = s.offset + int(offset)
 	} else if whence == io.SeekEnd {
-		newPos = s.size + int(offset)
+		newPos = int(size) + int(offset)
 	} else if whence == io.SeekStart {
 		newPos = int(offset)
 	}
-	if newPos > s.size || newPos < 0 {
+	// moving past the end is allowed, next write leaves a hole read as zeros
+	if newPos < 0 {
 		return 0, fmt.Errorf("Invalid offset %v", offset)
 	}
 	s.offset = newPos
 	return int64(newPos), nil
 }
 
+// Flush - mockup writes are not buffered
+func (s *MockupObjectStream) Flush() error {
+	return s.check()
+}
+
+// Abort - discard staged writes and close stream
+func (s *MockupObjectStream) Abort() error {
+	if !s.markClosed() {
+		return nil
+	}
+	s.h.Close()
+	if err := os.Remove(s.h.Name()); err != nil {
+		return fmt.Errorf("MockupObjectStream::Abort() file %v IO error: %v", s.path, err)
+	}
+	return nil
+}
+
+// Close - replace object file with staged copy if stream was written
 func (s *MockupObjectStream) Close() error {
+	if !s.markClosed() {
+		return nil
+	}
+	s.lock.Lock()
+	dirty := s.dirty
+	s.lock.Unlock()
+	if !dirty {
+		s.h.Close()
+		return os.Remove(s.h.Name())
+	}
 	err := s.h.Close()
+	if err == nil {
+		err = os.Rename(s.h.Name(), s.path)
+	}
 	if err != nil {
+		os.Remove(s.h.Name())
 		return fmt.Errorf("MockupObjectStream::Close() file %v IO error: %v", s.path, err)
 	}
 	return nil
 }
 
+// markClosed - mark stream closed, false if it was closed already
+func (s *MockupObjectStream) markClosed() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		return false
+	}
+	s.closed = true
+	return true
+}
+
 // CreateMockup - client structure constructor
 func CreateMockup(debug int) *Mockup {
 	mockup := new(Mockup)
 	mockup.Buckets = make(map[string]s3xApi.Bucket)
 	mockup.Objects = make(map[string]kvobj)
+	mockup.uploads = make(map[string]*mockupUpload)
 	mockup.Debug = debug
-	mockup.Sid = ""
 	mockup.Bucket = ""
 	mockup.Object = ""
 	f, err := ioutil.ReadFile(mockupPath)
@@ -139,7 +276,7 @@ func keyValueCommitNow(mockup *Mockup, bucket string, object string) error {
 	var uri = bucket + "/" + object
 	o, exists := mockup.Objects[uri]
 	if !exists {
-		return fmt.Errorf("Object %s/%s not found", bucket, object)
+		return fmt.Errorf("Object %s/%s: %w", bucket, object, s3xErrors.ErrObjectNotExist)
 	}
 	for key, value := range o.recent {
 		o.KeyValue[key] = value
@@ -153,6 +290,9 @@ func keyValueCommitNow(mockup *Mockup, bucket string, object string) error {
 	if len(o.recent) > 0 {
 		o.recent = make(map[string]string)
 	}
+	o.Modified = time.Now()
+	o.Generation++
+	mockup.Objects[uri] = o
 	return keyValueSync(mockup)
 }
 
@@ -161,6 +301,11 @@ func (mockup *Mockup) CloseEdgex() {
 	return
 }
 
+// Close - nothing to release in mockup
+func (mockup *Mockup) Close() error {
+	return nil
+}
+
 // BucketCreate - create a new bucket
 func (mockup *Mockup) BucketCreate(bucket string) error {
 	mockup.lock.Lock()
@@ -168,7 +313,7 @@ func (mockup *Mockup) BucketCreate(bucket string) error {
 
 	_, exists := mockup.Buckets[bucket]
 	if exists {
-		return fmt.Errorf("%s bucket already exists", bucket)
+		return fmt.Errorf("%s bucket: %w", bucket, s3xErrors.ErrBucketExist)
 	}
 
 	t := time.Now()
@@ -179,6 +324,12 @@ func (mockup *Mockup) BucketCreate(bucket string) error {
 // ObjectCreate - create object
 func (mockup *Mockup) ObjectCreate(bucket string, object string, objectType s3xApi.ObjectType,
 	contentType string, chunkSize int, btreeOrder int) error {
+	return mockup.ObjectCreateWithMetadata(bucket, object, objectType, contentType, chunkSize, btreeOrder, nil)
+}
+
+// ObjectCreateWithMetadata - create object with user metadata
+func (mockup *Mockup) ObjectCreateWithMetadata(bucket string, object string, objectType s3xApi.ObjectType,
+	contentType string, chunkSize int, btreeOrder int, meta map[string]string) error {
 	mockup.lock.Lock()
 	defer mockup.lock.Unlock()
 
@@ -191,10 +342,17 @@ func (mockup *Mockup) ObjectCreate(bucket string, object string, objectType s3xA
 	var uri = bucket + "/" + object
 	_, e := mockup.Objects[uri]
 	if e {
-		return fmt.Errorf("%s/%s already exists", bucket, object)
+		return fmt.Errorf("%s/%s: %w", bucket, object, s3xErrors.ErrObjectExist)
 	}
 
-	var kv kvobj
+	kv := kvobj{
+		ContentType: contentType,
+		ChunkSize:   chunkSize,
+		BTreeOrder:  btreeOrder,
+		Modified:    time.Now(),
+		Generation:  1,
+		Metadata:    copyMetadata(meta),
+	}
 	if objectType == s3xApi.OBJECT_TYPE_KEY_VALUE {
 		kv.KeyValue = make(map[string]string)
 		kv.recent = make(map[string]string)
@@ -231,7 +389,7 @@ func (mockup *Mockup) KeyValueRollback(bucket string, object string) error {
 	var uri = bucket + "/" + object
 	o, exists := mockup.Objects[uri]
 	if !exists {
-		return fmt.Errorf("Object %s/%s not found", bucket, object)
+		return fmt.Errorf("Object %s/%s: %w", bucket, object, s3xErrors.ErrObjectNotExist)
 	}
 	if len(o.recentDel) > 0 {
 		o.recentDel = nil
@@ -255,12 +413,31 @@ func (mockup *Mockup) ObjectDelete(bucket string, object string) error {
 	return keyValueSync(mockup)
 }
 
-func (mockup *Mockup) ObjectGetStream(bucket, object string) (s3xApi.ObjectStream, error) {
+func (mockup *Mockup) ObjectGetStream(bucket, object string, flags ...int) (s3xApi.ObjectStream, error) {
+	return mockup.ObjectGetStreamCtx(context.Background(), bucket, object, flags...)
+}
+
+// ObjectGetStreamCtx - open object stream bound to ctx
+func (mockup *Mockup) ObjectGetStreamCtx(ctx context.Context, bucket, object string, flags ...int) (s3xApi.ObjectStream, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	mode := 0
+	for _, flag := range flags {
+		mode |= flag
+	}
+	switch mode {
+	case s3xApi.SS_CONT:
+		mode = s3xApi.SS_RANDWR
+	case s3xApi.SS_RANDWR, s3xApi.SS_APPEND, s3xApi.SS_RDONLY:
+	default:
+		return nil, fmt.Errorf("ObjectGetStream() invalid stream open flags %#x", mode)
+	}
 	var path = bucket + "/" + object
 
 	kv, exists := mockup.Objects[path]
 	if !exists {
-		return nil, fmt.Errorf("ObjectGetStream() Object %v doesn't exist", path)
+		return nil, fmt.Errorf("ObjectGetStream() Object %v: %w", path, s3xErrors.ErrObjectNotExist)
 	}
 	if !kv.Stream {
 		return nil, fmt.Errorf("ObjectGetStream() Object %v doesn't support stream operations", path)
@@ -271,18 +448,47 @@ func (mockup *Mockup) ObjectGetStream(bucket, object string) (s3xApi.ObjectStrea
 		return nil, fmt.Errorf("ObjectGetStream() Internal: file %v doesn't support stream operations", path)
 	}
 	rc := &MockupObjectStream{
+		ctx:    ctx,
 		path:   path,
 		offset: 0,
+		mode:   mode,
 		size:   int(info.Size()),
 		dirty:  false,
 	}
-	rc.h, err = os.OpenFile(rc.path, os.O_RDWR|os.O_CREATE, 0755)
+	rc.h, err = stageFile(rc.path)
 	if err != nil {
-		return nil, fmt.Errorf("Mockup::ObjectGetStream() couldn't open file %v", rc.path)
+		return nil, fmt.Errorf("Mockup::ObjectGetStream() couldn't open file %v: %v", rc.path, err)
 	}
 	return rc, nil
 }
 
+// stageFile - open copy of file at path next to it, stream writes go there until Close
+func stageFile(path string) (*os.File, error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+	info, err := src.Stat()
+	if err != nil {
+		return nil, err
+	}
+	h, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".stage-*")
+	if err != nil {
+		return nil, err
+	}
+	_, err = io.Copy(h, src)
+	if err == nil {
+		err = h.Chmod(info.Mode())
+	}
+	if err != nil {
+		h.Close()
+		os.Remove(h.Name())
+		return nil, err
+	}
+	return h, nil
+}
+
 // BucketDelete - delete bucket
 func (mockup *Mockup) BucketDelete(bucket string) error {
 	mockup.lock.Lock()
@@ -326,7 +532,7 @@ func (mockup *Mockup) KeyValuePost(bucket string, object string,
 
 	o, exists := mockup.Objects[uri]
 	if !exists {
-		return fmt.Errorf("Object %s/%s not found", bucket, object)
+		return fmt.Errorf("Object %s/%s: %w", bucket, object, s3xErrors.ErrObjectNotExist)
 	}
 	if more {
 		o.recent[key] = value.String()
@@ -346,7 +552,7 @@ func (mockup *Mockup) KeyValuePostJSON(bucket string, object string,
 
 	o, exists := mockup.Objects[uri]
 	if !exists {
-		return fmt.Errorf("Object %s/%s not found", bucket, object)
+		return fmt.Errorf("Object %s/%s: %w", bucket, object, s3xErrors.ErrObjectNotExist)
 	}
 
 	if !more {
@@ -376,7 +582,7 @@ func (mockup *Mockup) KeyValueMapPost(bucket, object string, valuesMap s3xApi.S3
 
 	o, exists := mockup.Objects[uri]
 	if !exists {
-		return fmt.Errorf("Object %s/%s not found", bucket, object)
+		return fmt.Errorf("Object %s/%s: %w", bucket, object, s3xErrors.ErrObjectNotExist)
 	}
 
 	if !more {
@@ -409,7 +615,7 @@ func (mockup *Mockup) KeyValuePostCSV(bucket string, object string,
 
 	o, exists := mockup.Objects[uri]
 	if !exists {
-		return fmt.Errorf("Object %s/%s not found", bucket, object)
+		return fmt.Errorf("Object %s/%s: %w", bucket, object, s3xErrors.ErrObjectNotExist)
 	}
 
 	if !more {
@@ -442,7 +648,7 @@ func (mockup *Mockup) KeyValueDelete(bucket string, object string,
 
 	o, exists := mockup.Objects[uri]
 	if !exists {
-		return fmt.Errorf("Object %s/%s not found", bucket, object)
+		return fmt.Errorf("Object %s/%s: %w", bucket, object, s3xErrors.ErrObjectNotExist)
 	}
 
 	if !more {
@@ -467,7 +673,7 @@ func (mockup *Mockup) KeyValueMapDelete(bucket string, object string,
 
 	o, exists := mockup.Objects[uri]
 	if !exists {
-		return fmt.Errorf("Object %s/%s not found", bucket, object)
+		return fmt.Errorf("Object %s/%s: %w", bucket, object, s3xErrors.ErrObjectNotExist)
 	}
 
 	if !more {
@@ -494,7 +700,7 @@ func (mockup *Mockup) KeyValueDeleteJSON(bucket string, object string,
 
 	o, exists := mockup.Objects[uri]
 	if !exists {
-		return fmt.Errorf("Object %s/%s not found", bucket, object)
+		return fmt.Errorf("Object %s/%s: %w", bucket, object, s3xErrors.ErrObjectNotExist)
 	}
 
 	if !more {
@@ -525,12 +731,12 @@ func (mockup *Mockup) KeyValueGet(bucket string, object string, key string) (str
 	//fmt.Printf("Objects: %#v\n", mockup.objects)
 	o, exists := mockup.Objects[uri]
 	if !exists {
-		return str, fmt.Errorf("Object %s/%s not found", bucket, object)
+		return str, fmt.Errorf("Object %s/%s: %w", bucket, object, s3xErrors.ErrObjectNotExist)
 	}
 
 	v, e := o.KeyValue[key]
 	if !e {
-		return str, fmt.Errorf("Object %s/%s key %s not found", bucket, object, key)
+		return str, fmt.Errorf("Object %s/%s key %s: %w", bucket, object, key, s3xErrors.ErrKeyNotExist)
 	}
 	return v, nil
 }
@@ -546,7 +752,7 @@ func (mockup *Mockup) KeyValueList(bucket string, object string,
 
 	o, exists := mockup.Objects[uri]
 	if !exists {
-		return str, fmt.Errorf("Object %s/%s not found", bucket, object)
+		return str, fmt.Errorf("Object %s/%s: %w", bucket, object, s3xErrors.ErrObjectNotExist)
 	}
 
 	keys := make([]string, 0, len(o.KeyValue))
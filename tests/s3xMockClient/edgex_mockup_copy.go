@@ -0,0 +1,104 @@
+package s3xMockClient
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+)
+
+// ObjectCopy - copy stream or key/value object, metadata is preserved unless replaced by opts
+func (mockup *Mockup) ObjectCopy(srcBucket, srcObject, dstBucket, dstObject string, opts s3xApi.CopyOptions) error {
+	mockup.lock.Lock()
+	defer mockup.lock.Unlock()
+
+	var srcUri = srcBucket + "/" + srcObject
+	src, exists := mockup.Objects[srcUri]
+	if !exists {
+		return fmt.Errorf("Object %v: %w", srcUri, s3xErrors.ErrObjectNotExist)
+	}
+	if _, exists := mockup.Buckets[dstBucket]; !exists {
+		return fmt.Errorf("Bucket %v: %w", dstBucket, s3xErrors.ErrBucketNotExist)
+	}
+
+	var dstUri = dstBucket + "/" + dstObject
+	dst := kvobj{
+		Stream:      src.Stream,
+		ContentType: src.ContentType,
+		ChunkSize:   src.ChunkSize,
+		BTreeOrder:  src.BTreeOrder,
+		Modified:    time.Now(),
+		Generation:  mockup.Objects[dstUri].Generation + 1,
+		Metadata:    copyMetadata(src.Metadata),
+	}
+	if opts.ContentType != "" {
+		dst.ContentType = opts.ContentType
+	}
+	if opts.Metadata != nil {
+		dst.Metadata = copyMetadata(opts.Metadata)
+	}
+
+	if src.Stream {
+		data, err := os.ReadFile(streamFileDir + srcUri)
+		if err != nil {
+			return fmt.Errorf("Couldn't read file %v: %v", streamFileDir+srcUri, err)
+		}
+		if err := os.MkdirAll(streamFileDir+dstBucket, 0777); err != nil {
+			return fmt.Errorf("Couldn't crete folder %v: %v", streamFileDir+dstBucket, err)
+		}
+		if err := os.WriteFile(streamFileDir+dstUri, data, 0755); err != nil {
+			return fmt.Errorf("Couldn't write file %v: %v", streamFileDir+dstUri, err)
+		}
+	} else {
+		dst.KeyValue = make(map[string]string, len(src.KeyValue))
+		dst.recent = make(map[string]string)
+		for key, value := range src.KeyValue {
+			dst.KeyValue[key] = value
+		}
+	}
+	mockup.Objects[dstUri] = dst
+	return keyValueSync(mockup)
+}
+
+// ObjectCopyCtx - copy stream or key/value object within ctx
+func (mockup *Mockup) ObjectCopyCtx(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string, opts s3xApi.CopyOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mockup.ObjectCopy(srcBucket, srcObject, dstBucket, dstObject, opts)
+}
+
+// ObjectRename - copy object to new name and delete source
+func (mockup *Mockup) ObjectRename(srcBucket, srcObject, dstBucket, dstObject string) error {
+	if srcBucket == dstBucket && srcObject == dstObject {
+		return fmt.Errorf("ObjectRename() Object %v/%v onto itself: %w", srcBucket, srcObject, s3xErrors.ErrInvalidArgument)
+	}
+	if err := mockup.ObjectCopy(srcBucket, srcObject, dstBucket, dstObject, s3xApi.CopyOptions{}); err != nil {
+		return err
+	}
+	return mockup.ObjectDelete(srcBucket, srcObject)
+}
+
+// ObjectRenameCtx - copy object to new name and delete source within ctx
+func (mockup *Mockup) ObjectRenameCtx(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mockup.ObjectRename(srcBucket, srcObject, dstBucket, dstObject)
+}
+
+// copyMetadata - copy of user metadata with lower case keys
+func copyMetadata(meta map[string]string) map[string]string {
+	if meta == nil {
+		return nil
+	}
+	copied := make(map[string]string, len(meta))
+	for key, value := range meta {
+		copied[strings.ToLower(key)] = value
+	}
+	return copied
+}
@@ -0,0 +1,169 @@
+package s3xMockClient
+
+import (
+	"bytes"
+	"context"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+)
+
+// Mockup implements both plain and context-first client interfaces
+var (
+	_ s3xApi.S3xClient    = (*Mockup)(nil)
+	_ s3xApi.S3xClientCtx = (*Mockup)(nil)
+)
+
+// BucketListCtx - read bucket list within ctx
+func (mockup *Mockup) BucketListCtx(ctx context.Context) ([]s3xApi.Bucket, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return mockup.BucketList()
+}
+
+// BucketHeadCtx - read bucket header fields within ctx
+func (mockup *Mockup) BucketHeadCtx(ctx context.Context, bucket string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mockup.BucketHead(bucket)
+}
+
+// BucketCreateCtx - create a new bucket within ctx
+func (mockup *Mockup) BucketCreateCtx(ctx context.Context, bucket string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mockup.BucketCreate(bucket)
+}
+
+// BucketDeleteCtx - delete bucket within ctx
+func (mockup *Mockup) BucketDeleteCtx(ctx context.Context, bucket string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mockup.BucketDelete(bucket)
+}
+
+// ObjectListCtx - read object list from bucket within ctx
+func (mockup *Mockup) ObjectListCtx(ctx context.Context, bucket, from, pattern string, maxcount int) ([]s3xApi.Object, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return mockup.ObjectList(bucket, from, pattern, maxcount)
+}
+
+// ObjectHeadCtx - read object header fields within ctx
+func (mockup *Mockup) ObjectHeadCtx(ctx context.Context, bucket, object string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mockup.ObjectHead(bucket, object)
+}
+
+// ObjectCreateCtx - create object within ctx
+func (mockup *Mockup) ObjectCreateCtx(ctx context.Context, bucket, object string, objectType s3xApi.ObjectType,
+	contentType string, chunkSize int, btreeOrder int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mockup.ObjectCreate(bucket, object, objectType, contentType, chunkSize, btreeOrder)
+}
+
+// ObjectDeleteCtx - delete object within ctx
+func (mockup *Mockup) ObjectDeleteCtx(ctx context.Context, bucket, object string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mockup.ObjectDelete(bucket, object)
+}
+
+// KeyValueGetCtx - read object value field within ctx
+func (mockup *Mockup) KeyValueGetCtx(ctx context.Context, bucket, object, key string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return mockup.KeyValueGet(bucket, object, key)
+}
+
+// KeyValuePostCtx - post key/value pairs within ctx
+func (mockup *Mockup) KeyValuePostCtx(ctx context.Context, bucket, object, key string,
+	value *bytes.Buffer, contentType string, more bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mockup.KeyValuePost(bucket, object, key, value, contentType, more)
+}
+
+// KeyValueDeleteCtx - delete key/value pair within ctx
+func (mockup *Mockup) KeyValueDeleteCtx(ctx context.Context, bucket, object, key string, more bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mockup.KeyValueDelete(bucket, object, key, more)
+}
+
+// KeyValueMapPostCtx - post key/value map within ctx
+func (mockup *Mockup) KeyValueMapPostCtx(ctx context.Context, bucket, object string, values s3xApi.S3xKVMap, more bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mockup.KeyValueMapPost(bucket, object, values, more)
+}
+
+// KeyValueMapDeleteCtx - delete key/value map within ctx
+func (mockup *Mockup) KeyValueMapDeleteCtx(ctx context.Context, bucket, object string, values s3xApi.S3xKVMap, more bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mockup.KeyValueMapDelete(bucket, object, values, more)
+}
+
+// KeyValuePostJSONCtx - post key/value pairs within ctx
+func (mockup *Mockup) KeyValuePostJSONCtx(ctx context.Context, bucket, object, keyValueJSON string, more bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mockup.KeyValuePostJSON(bucket, object, keyValueJSON, more)
+}
+
+// KeyValuePostCSVCtx - post key/value pairs presented like csv within ctx
+func (mockup *Mockup) KeyValuePostCSVCtx(ctx context.Context, bucket, object, keyValueCSV string, more bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mockup.KeyValuePostCSV(bucket, object, keyValueCSV, more)
+}
+
+// KeyValueDeleteJSONCtx - delete key/value pairs defined by json within ctx
+func (mockup *Mockup) KeyValueDeleteJSONCtx(ctx context.Context, bucket, object, keyValueJSON string, more bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mockup.KeyValueDeleteJSON(bucket, object, keyValueJSON, more)
+}
+
+// KeyValueListCtx - read key/value pairs within ctx
+func (mockup *Mockup) KeyValueListCtx(ctx context.Context, bucket, object, from, pattern, contentType string,
+	maxcount int, values bool) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return mockup.KeyValueList(bucket, object, from, pattern, contentType, maxcount, values)
+}
+
+// KeyValueCommitCtx - commit key/value insert/update/delete within ctx
+func (mockup *Mockup) KeyValueCommitCtx(ctx context.Context, bucket, object string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mockup.KeyValueCommit(bucket, object)
+}
+
+// KeyValueRollbackCtx - rollback key/value insert/update/delete session within ctx
+func (mockup *Mockup) KeyValueRollbackCtx(ctx context.Context, bucket, object string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mockup.KeyValueRollback(bucket, object)
+}
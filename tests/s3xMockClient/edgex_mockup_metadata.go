@@ -0,0 +1,49 @@
+package s3xMockClient
+
+import (
+	"context"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+)
+
+// ObjectCreateWithMetadataCtx - create object with user metadata within ctx
+func (mockup *Mockup) ObjectCreateWithMetadataCtx(ctx context.Context, bucket, object string, objectType s3xApi.ObjectType,
+	contentType string, chunkSize int, btreeOrder int, meta map[string]string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mockup.ObjectCreateWithMetadata(bucket, object, objectType, contentType, chunkSize, btreeOrder, meta)
+}
+
+// ObjectSetMetadata - replace all user metadata of object
+func (mockup *Mockup) ObjectSetMetadata(bucket, object string, meta map[string]string) error {
+	if meta == nil {
+		meta = map[string]string{}
+	}
+	return mockup.ObjectCopy(bucket, object, bucket, object, s3xApi.CopyOptions{Metadata: meta})
+}
+
+// ObjectSetMetadataCtx - replace all user metadata of object within ctx
+func (mockup *Mockup) ObjectSetMetadataCtx(ctx context.Context, bucket, object string, meta map[string]string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mockup.ObjectSetMetadata(bucket, object, meta)
+}
+
+// ObjectGetMetadata - user metadata of object, nil if object has none
+func (mockup *Mockup) ObjectGetMetadata(bucket, object string) (map[string]string, error) {
+	info, err := mockup.ObjectStat(bucket, object)
+	if err != nil {
+		return nil, err
+	}
+	return info.Metadata, nil
+}
+
+// ObjectGetMetadataCtx - user metadata of object within ctx
+func (mockup *Mockup) ObjectGetMetadataCtx(ctx context.Context, bucket, object string) (map[string]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return mockup.ObjectGetMetadata(bucket, object)
+}
@@ -0,0 +1,276 @@
+package s3xMockClient
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+)
+
+// mockup multipart upload part size default
+const mockupPartSize = 8 * 1024 * 1024
+
+// mockupUpload - multipart upload in progress
+type mockupUpload struct {
+	bucket      string
+	object      string
+	contentType string
+	meta        map[string]string
+	initiated   time.Time
+	parts       map[int][]byte
+}
+
+// upload - multipart upload uploadID of bucket/object, called under lock
+func (mockup *Mockup) upload(bucket, object, uploadID string) (*mockupUpload, error) {
+	upload, exists := mockup.uploads[uploadID]
+	if !exists || upload.bucket != bucket || upload.object != object {
+		return nil, fmt.Errorf("Upload %v of %s/%s: %w", uploadID, bucket, object, s3xErrors.ErrUploadNotExist)
+	}
+	return upload, nil
+}
+
+func partETag(data []byte) string {
+	sum := md5.Sum(data)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// MultipartInitiate - start multipart upload of object
+func (mockup *Mockup) MultipartInitiate(bucket, object, contentType string, meta map[string]string) (string, error) {
+	mockup.lock.Lock()
+	defer mockup.lock.Unlock()
+
+	if _, exists := mockup.Buckets[bucket]; !exists {
+		return "", fmt.Errorf("Bucket %v: %w", bucket, s3xErrors.ErrBucketNotExist)
+	}
+	if mockup.uploads == nil {
+		mockup.uploads = make(map[string]*mockupUpload)
+	}
+	now := time.Now()
+	uploadID := fmt.Sprintf("%x-%d", now.UnixNano(), len(mockup.uploads))
+	mockup.uploads[uploadID] = &mockupUpload{
+		bucket:      bucket,
+		object:      object,
+		contentType: contentType,
+		meta:        meta,
+		initiated:   now,
+		parts:       make(map[int][]byte),
+	}
+	return uploadID, nil
+}
+
+// MultipartUploadPart - upload part partNumber of size bytes read from r
+func (mockup *Mockup) MultipartUploadPart(bucket, object, uploadID string, partNumber int, r io.Reader, size int64) (s3xApi.Part, error) {
+	if partNumber < 1 || partNumber > 10000 {
+		return s3xApi.Part{}, fmt.Errorf("MultipartUploadPart() invalid part number %d", partNumber)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return s3xApi.Part{}, err
+	}
+	if int64(len(data)) != size {
+		return s3xApi.Part{}, fmt.Errorf("MultipartUploadPart() read %d bytes, expected %d", len(data), size)
+	}
+
+	mockup.lock.Lock()
+	defer mockup.lock.Unlock()
+	upload, err := mockup.upload(bucket, object, uploadID)
+	if err != nil {
+		return s3xApi.Part{}, err
+	}
+	upload.parts[partNumber] = data
+	return s3xApi.Part{PartNumber: partNumber, ETag: partETag(data), Size: size}, nil
+}
+
+// MultipartComplete - assemble uploaded parts into object
+func (mockup *Mockup) MultipartComplete(bucket, object, uploadID string, parts []s3xApi.Part) error {
+	if len(parts) == 0 {
+		return fmt.Errorf("MultipartComplete() %s/%s: no parts", bucket, object)
+	}
+	sorted := append([]s3xApi.Part(nil), parts...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].PartNumber < sorted[j].PartNumber })
+
+	mockup.lock.Lock()
+	upload, err := mockup.upload(bucket, object, uploadID)
+	if err != nil {
+		mockup.lock.Unlock()
+		return err
+	}
+	var data bytes.Buffer
+	for _, part := range sorted {
+		content, exists := upload.parts[part.PartNumber]
+		if !exists || partETag(content) != part.ETag {
+			mockup.lock.Unlock()
+			return fmt.Errorf("MultipartComplete() %s/%s: invalid part %d", bucket, object, part.PartNumber)
+		}
+		data.Write(content)
+	}
+	delete(mockup.uploads, uploadID)
+	mockup.lock.Unlock()
+
+	return mockup.ObjectPut(bucket, object, &data, int64(data.Len()), upload.contentType, upload.meta)
+}
+
+// MultipartAbort - discard multipart upload
+func (mockup *Mockup) MultipartAbort(bucket, object, uploadID string) error {
+	mockup.lock.Lock()
+	defer mockup.lock.Unlock()
+	if _, err := mockup.upload(bucket, object, uploadID); err != nil {
+		return err
+	}
+	delete(mockup.uploads, uploadID)
+	return nil
+}
+
+// MultipartListParts - parts uploaded so far
+func (mockup *Mockup) MultipartListParts(bucket, object, uploadID string) ([]s3xApi.Part, error) {
+	mockup.lock.Lock()
+	defer mockup.lock.Unlock()
+	upload, err := mockup.upload(bucket, object, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	parts := make([]s3xApi.Part, 0, len(upload.parts))
+	for number, data := range upload.parts {
+		parts = append(parts, s3xApi.Part{PartNumber: number, ETag: partETag(data), Size: int64(len(data))})
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+	return parts, nil
+}
+
+// MultipartListUploads - multipart uploads in progress of bucket objects starting with prefix
+func (mockup *Mockup) MultipartListUploads(bucket, prefix string) ([]s3xApi.MultipartUpload, error) {
+	mockup.lock.Lock()
+	defer mockup.lock.Unlock()
+	if _, exists := mockup.Buckets[bucket]; !exists {
+		return nil, fmt.Errorf("Bucket %v: %w", bucket, s3xErrors.ErrBucketNotExist)
+	}
+	var uploads []s3xApi.MultipartUpload
+	for uploadID, upload := range mockup.uploads {
+		if upload.bucket == bucket && strings.HasPrefix(upload.object, prefix) {
+			uploads = append(uploads, s3xApi.MultipartUpload{
+				Key:       upload.object,
+				UploadID:  uploadID,
+				Initiated: upload.initiated.Format(time.RFC3339),
+			})
+		}
+	}
+	sort.Slice(uploads, func(i, j int) bool {
+		if uploads[i].Key != uploads[j].Key {
+			return uploads[i].Key < uploads[j].Key
+		}
+		return uploads[i].UploadID < uploads[j].UploadID
+	})
+	return uploads, nil
+}
+
+// ObjectUploadMultipart - upload r in parts of opts.PartSize, aborted on failure
+func (mockup *Mockup) ObjectUploadMultipart(bucket, object string, r io.Reader, opts s3xApi.TransferOptions) (int64, error) {
+	return mockup.ObjectUploadMultipartCtx(context.Background(), bucket, object, r, opts)
+}
+
+// ObjectUploadMultipartCtx - upload r in parts of opts.PartSize within ctx
+func (mockup *Mockup) ObjectUploadMultipartCtx(ctx context.Context, bucket, object string, r io.Reader, opts s3xApi.TransferOptions) (int64, error) {
+	if opts.PartSize <= 0 {
+		opts.PartSize = mockupPartSize
+	}
+	uploadID, err := mockup.MultipartInitiate(bucket, object, opts.ContentType, opts.Metadata)
+	if err != nil {
+		return 0, err
+	}
+
+	var parts []s3xApi.Part
+	var size int64
+	for number := 1; ; number++ {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		buf := make([]byte, opts.PartSize)
+		n, readErr := io.ReadFull(r, buf)
+		last := readErr == io.EOF || readErr == io.ErrUnexpectedEOF
+		if readErr != nil && !last {
+			err = readErr
+			break
+		}
+		if n == 0 && number > 1 {
+			break
+		}
+		var part s3xApi.Part
+		part, err = mockup.MultipartUploadPart(bucket, object, uploadID, number, bytes.NewReader(buf[:n]), int64(n))
+		if err != nil {
+			break
+		}
+		parts = append(parts, part)
+		size += int64(n)
+		if opts.Progress != nil {
+			opts.Progress(size, -1)
+		}
+		if last {
+			break
+		}
+	}
+
+	if err == nil {
+		err = mockup.MultipartComplete(bucket, object, uploadID, parts)
+	}
+	if err != nil {
+		mockup.MultipartAbort(bucket, object, uploadID)
+		return 0, err
+	}
+	return size, nil
+}
+
+// MultipartInitiateCtx - start multipart upload of object within ctx
+func (mockup *Mockup) MultipartInitiateCtx(ctx context.Context, bucket, object, contentType string, meta map[string]string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return mockup.MultipartInitiate(bucket, object, contentType, meta)
+}
+
+// MultipartUploadPartCtx - upload part partNumber within ctx
+func (mockup *Mockup) MultipartUploadPartCtx(ctx context.Context, bucket, object, uploadID string, partNumber int, r io.Reader, size int64) (s3xApi.Part, error) {
+	if err := ctx.Err(); err != nil {
+		return s3xApi.Part{}, err
+	}
+	return mockup.MultipartUploadPart(bucket, object, uploadID, partNumber, r, size)
+}
+
+// MultipartCompleteCtx - assemble uploaded parts into object within ctx
+func (mockup *Mockup) MultipartCompleteCtx(ctx context.Context, bucket, object, uploadID string, parts []s3xApi.Part) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mockup.MultipartComplete(bucket, object, uploadID, parts)
+}
+
+// MultipartAbortCtx - discard multipart upload within ctx
+func (mockup *Mockup) MultipartAbortCtx(ctx context.Context, bucket, object, uploadID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mockup.MultipartAbort(bucket, object, uploadID)
+}
+
+// MultipartListPartsCtx - parts uploaded so far within ctx
+func (mockup *Mockup) MultipartListPartsCtx(ctx context.Context, bucket, object, uploadID string) ([]s3xApi.Part, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return mockup.MultipartListParts(bucket, object, uploadID)
+}
+
+// MultipartListUploadsCtx - multipart uploads in progress within ctx
+func (mockup *Mockup) MultipartListUploadsCtx(ctx context.Context, bucket, prefix string) ([]s3xApi.MultipartUpload, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return mockup.MultipartListUploads(bucket, prefix)
+}
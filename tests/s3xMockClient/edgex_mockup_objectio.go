@@ -0,0 +1,108 @@
+package s3xMockClient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+)
+
+// ObjectPut - store whole object read from r
+func (mockup *Mockup) ObjectPut(bucket, object string, r io.Reader, size int64, contentType string, meta map[string]string) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if size >= 0 && int64(len(data)) != size {
+		return fmt.Errorf("ObjectPut() %s/%s read %d bytes, expected %d", bucket, object, len(data), size)
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	mockup.lock.Lock()
+	defer mockup.lock.Unlock()
+
+	if _, exists := mockup.Buckets[bucket]; !exists {
+		mockup.Buckets[bucket] = s3xApi.Bucket{Name: bucket, CreationDate: time.Now().Format(time.RFC3339)}
+	}
+	dir := streamFileDir + bucket
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return fmt.Errorf("Couldn't crete folder %v: %v", dir, err)
+	}
+	var uri = bucket + "/" + object
+	if err := os.WriteFile(streamFileDir+uri, data, 0755); err != nil {
+		return fmt.Errorf("Couldn't write file %v: %v", streamFileDir+uri, err)
+	}
+
+	kv := kvobj{
+		Stream:      true,
+		ContentType: contentType,
+		ChunkSize:   s3xApi.DEFAULT_CHUNKSIZE,
+		BTreeOrder:  s3xApi.DEFAULT_BTREE_ORDER,
+		Modified:    time.Now(),
+		Generation:  mockup.Objects[uri].Generation + 1,
+		Metadata:    copyMetadata(meta),
+	}
+	mockup.Objects[uri] = kv
+	return keyValueSync(mockup)
+}
+
+// ObjectPutCtx - store whole object read from r within ctx
+func (mockup *Mockup) ObjectPutCtx(ctx context.Context, bucket, object string, r io.Reader, size int64, contentType string, meta map[string]string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mockup.ObjectPut(bucket, object, r, size, contentType, meta)
+}
+
+// ObjectGet - read whole object
+func (mockup *Mockup) ObjectGet(bucket, object string) (io.ReadCloser, s3xApi.ObjectInfo, error) {
+	return mockup.ObjectGetRange(bucket, object, 0, -1)
+}
+
+// ObjectGetCtx - read whole object within ctx
+func (mockup *Mockup) ObjectGetCtx(ctx context.Context, bucket, object string) (io.ReadCloser, s3xApi.ObjectInfo, error) {
+	return mockup.ObjectGetRangeCtx(ctx, bucket, object, 0, -1)
+}
+
+// ObjectGetRange - read length bytes of object at offset, length -1 reads up to the end
+func (mockup *Mockup) ObjectGetRange(bucket, object string, offset, length int64) (io.ReadCloser, s3xApi.ObjectInfo, error) {
+	if offset < 0 || length == 0 || length < -1 {
+		return nil, s3xApi.ObjectInfo{}, fmt.Errorf("Invalid range %v+%v", offset, length)
+	}
+	info, err := mockup.ObjectStat(bucket, object)
+	if err != nil {
+		return nil, s3xApi.ObjectInfo{}, err
+	}
+	path, err := mockup.streamFile(bucket, object)
+	if err != nil {
+		return nil, s3xApi.ObjectInfo{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, s3xApi.ObjectInfo{}, err
+	}
+
+	if offset > 0 && offset >= int64(len(data)) {
+		return nil, s3xApi.ObjectInfo{}, fmt.Errorf("%s/%s range %v+%v: %w", bucket, object, offset, length, s3xErrors.ErrInvalidRange)
+	}
+	data = data[offset:]
+	if length > 0 && length < int64(len(data)) {
+		data = data[:length]
+	}
+	return io.NopCloser(bytes.NewReader(data)), info, nil
+}
+
+// ObjectGetRangeCtx - read length bytes of object at offset within ctx
+func (mockup *Mockup) ObjectGetRangeCtx(ctx context.Context, bucket, object string, offset, length int64) (io.ReadCloser, s3xApi.ObjectInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, s3xApi.ObjectInfo{}, err
+	}
+	return mockup.ObjectGetRange(bucket, object, offset, length)
+}
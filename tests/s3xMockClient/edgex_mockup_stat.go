@@ -0,0 +1,80 @@
+package s3xMockClient
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+)
+
+// ObjectStat - object size, type, layout and metadata
+func (mockup *Mockup) ObjectStat(bucket, object string) (s3xApi.ObjectInfo, error) {
+	mockup.lock.Lock()
+	defer mockup.lock.Unlock()
+
+	var uri = bucket + "/" + object
+	kv, exists := mockup.Objects[uri]
+	if !exists {
+		return s3xApi.ObjectInfo{}, fmt.Errorf("Object %v: %w", uri, s3xErrors.ErrObjectNotExist)
+	}
+
+	info := s3xApi.ObjectInfo{
+		Bucket:       bucket,
+		Object:       object,
+		ContentType:  kv.ContentType,
+		LastModified: kv.Modified,
+		ObjectType:   s3xApi.OBJECT_TYPE_KEY_VALUE,
+		ChunkSize:    kv.ChunkSize,
+		BTreeOrder:   kv.BTreeOrder,
+		Generation:   kv.Generation,
+	}
+	if len(kv.Metadata) > 0 {
+		info.Metadata = make(map[string]string, len(kv.Metadata))
+		for key, value := range kv.Metadata {
+			info.Metadata[key] = value
+		}
+	}
+
+	h := md5.New()
+	if kv.Stream {
+		info.ObjectType = s3xApi.OBJECT_TYPE_OBJECT
+		data, err := os.ReadFile(streamFileDir + uri)
+		if err != nil {
+			return s3xApi.ObjectInfo{}, fmt.Errorf("Object %v stream file read error: %v", uri, err)
+		}
+		stat, err := os.Stat(streamFileDir + uri)
+		if err == nil && stat.ModTime().After(info.LastModified) {
+			info.LastModified = stat.ModTime()
+		}
+		info.Size = int64(len(data))
+		h.Write(data)
+	} else {
+		keys := make([]string, 0, len(kv.KeyValue))
+		for key := range kv.KeyValue {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			h.Write([]byte(key + ";" + kv.KeyValue[key] + "\n"))
+		}
+		// key count stands for logical size of key/value object
+		info.Size = int64(len(keys))
+	}
+	info.ETag = hex.EncodeToString(h.Sum(nil))
+	info.VersionID = strconv.FormatUint(kv.Generation, 10)
+	return info, nil
+}
+
+// ObjectStatCtx - object size, type, layout and metadata within ctx
+func (mockup *Mockup) ObjectStatCtx(ctx context.Context, bucket, object string) (s3xApi.ObjectInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return s3xApi.ObjectInfo{}, err
+	}
+	return mockup.ObjectStat(bucket, object)
+}
@@ -0,0 +1,152 @@
+package s3xMockClient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+)
+
+// streamFile - stream file of bucket/object
+func (mockup *Mockup) streamFile(bucket, object string) (string, error) {
+	mockup.lock.Lock()
+	defer mockup.lock.Unlock()
+
+	path := bucket + "/" + object
+	kv, exists := mockup.Objects[path]
+	if !exists {
+		return "", fmt.Errorf("Object %v: %w", path, s3xErrors.ErrObjectNotExist)
+	}
+	if !kv.Stream {
+		return "", fmt.Errorf("Object %v doesn't support stream operations", path)
+	}
+	return streamFileDir + path, nil
+}
+
+// checksum - verify data against expected hex checksum of opts
+func checksum(data []byte, opts s3xApi.TransferOptions) error {
+	if opts.Checksum == "" {
+		return nil
+	}
+	h := sha256.New()
+	if opts.Hash != nil {
+		h = opts.Hash()
+	}
+	h.Write(data)
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != opts.Checksum {
+		return fmt.Errorf("checksum %s, expected %s: %w", sum, opts.Checksum, s3xErrors.ErrChecksum)
+	}
+	return nil
+}
+
+// ObjectDownload - copy stream object into dst
+func (mockup *Mockup) ObjectDownload(bucket, object string, dst io.WriterAt, opts s3xApi.TransferOptions) (int64, error) {
+	return mockup.ObjectDownloadCtx(context.Background(), bucket, object, dst, opts)
+}
+
+// ObjectDownloadCtx - copy stream object into dst within ctx
+func (mockup *Mockup) ObjectDownloadCtx(ctx context.Context, bucket, object string, dst io.WriterAt, opts s3xApi.TransferOptions) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	path, err := mockup.streamFile(bucket, object)
+	if err != nil {
+		return 0, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	if err := checksum(data, opts); err != nil {
+		return 0, err
+	}
+	if _, err := dst.WriteAt(data, 0); err != nil {
+		return 0, err
+	}
+	progress(int64(len(data)), opts)
+	return int64(len(data)), nil
+}
+
+// ObjectUpload - replace stream object content with size bytes of src
+func (mockup *Mockup) ObjectUpload(bucket, object string, src io.ReaderAt, size int64, opts s3xApi.TransferOptions) error {
+	return mockup.ObjectUploadCtx(context.Background(), bucket, object, src, size, opts)
+}
+
+// ObjectUploadCtx - replace stream object content with size bytes of src within ctx
+func (mockup *Mockup) ObjectUploadCtx(ctx context.Context, bucket, object string, src io.ReaderAt, size int64, opts s3xApi.TransferOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = s3xApi.DEFAULT_CHUNKSIZE
+	}
+	if opts.ContentType == "" {
+		opts.ContentType = "application/octet-stream"
+	}
+	if err := mockup.recreateStream(bucket, object, opts); err != nil {
+		return err
+	}
+	path, err := mockup.streamFile(bucket, object)
+	if err != nil {
+		return err
+	}
+
+	data := make([]byte, size)
+	if n, err := src.ReadAt(data, 0); err != nil && !(err == io.EOF && int64(n) == size) {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0755); err != nil {
+		return err
+	}
+	progress(size, opts)
+	return nil
+}
+
+// recreateStream - create stream object with upload options, existing object is replaced
+// by its next version like the client does
+func (mockup *Mockup) recreateStream(bucket, object string, opts s3xApi.TransferOptions) error {
+	uri := bucket + "/" + object
+	mockup.lock.Lock()
+	current, exists := mockup.Objects[uri]
+	mockup.lock.Unlock()
+	generation := current.Generation
+	if exists {
+		if err := mockup.ObjectDelete(bucket, object); err != nil {
+			return err
+		}
+	}
+	err := mockup.ObjectCreateWithMetadata(bucket, object, s3xApi.OBJECT_TYPE_OBJECT, opts.ContentType,
+		opts.ChunkSize, s3xApi.DEFAULT_BTREE_ORDER, opts.Metadata)
+	if err != nil {
+		return err
+	}
+	mockup.lock.Lock()
+	defer mockup.lock.Unlock()
+	kv := mockup.Objects[uri]
+	kv.Generation = generation + 1
+	mockup.Objects[uri] = kv
+	return keyValueSync(mockup)
+}
+
+// progress - report transfer of size bytes part by part like the client does
+func progress(size int64, opts s3xApi.TransferOptions) {
+	if opts.Progress == nil {
+		return
+	}
+	partSize := int64(opts.PartSize)
+	if partSize <= 0 {
+		partSize = mockupPartSize
+	}
+	for done := int64(0); done < size; {
+		done += partSize
+		if done > size {
+			done = size
+		}
+		opts.Progress(done, size)
+	}
+}
@@ -0,0 +1,49 @@
+package s3xMockClient
+
+import (
+	"context"
+	"fmt"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+)
+
+// Truncate - set staged stream file size, growing file leaves a hole read as zeros.
+// Object file is changed on Close only
+func (m *MockupObjectStream) Truncate(size int64) error {
+	if err := m.check(); err != nil {
+		return err
+	}
+	if m.mode != s3xApi.SS_RANDWR {
+		return fmt.Errorf("Truncate %v: %w", m.path, s3xErrors.ErrStreamMode)
+	}
+	if size < 0 {
+		return fmt.Errorf("Invalid size %v", size)
+	}
+	if err := m.h.Truncate(size); err != nil {
+		return fmt.Errorf("File %v truncate error: %v", m.path, err)
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.size = int(size)
+	m.dirty = true
+	return nil
+}
+
+// ObjectTruncate - set stream object size
+func (mockup *Mockup) ObjectTruncate(bucket, object string, size int64) error {
+	return mockup.ObjectTruncateCtx(context.Background(), bucket, object, size)
+}
+
+// ObjectTruncateCtx - set stream object size within ctx
+func (mockup *Mockup) ObjectTruncateCtx(ctx context.Context, bucket, object string, size int64) error {
+	stream, err := mockup.ObjectGetStreamCtx(ctx, bucket, object)
+	if err != nil {
+		return err
+	}
+	if err := stream.Truncate(size); err != nil {
+		stream.Abort()
+		return err
+	}
+	return stream.Close()
+}
@@ -0,0 +1,169 @@
+package s3xMockClient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	s3xApi "github.com/highpeakdata/edgex-go-connector/api/s3xclient/v1beta1"
+	s3xErrors "github.com/highpeakdata/edgex-go-connector/pkg/errors"
+)
+
+// mockupKVOp - pending transaction update
+type mockupKVOp struct {
+	key    string
+	value  string
+	delete bool
+}
+
+// MockupKVTx - k/v transaction keeping its updates apart from other transactions until Commit
+type MockupKVTx struct {
+	mockup *Mockup
+	ctx    context.Context
+	bucket string
+	object string
+
+	lock sync.Mutex
+	ops  []mockupKVOp
+	done bool
+}
+
+// BeginKV - start k/v transaction
+func (mockup *Mockup) BeginKV(bucket, object string) (s3xApi.KVTx, error) {
+	return mockup.BeginKVCtx(context.Background(), bucket, object)
+}
+
+// BeginKVCtx - start k/v transaction bound to ctx
+func (mockup *Mockup) BeginKVCtx(ctx context.Context, bucket, object string) (s3xApi.KVTx, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return &MockupKVTx{
+		mockup: mockup,
+		ctx:    ctx,
+		bucket: bucket,
+		object: object,
+	}, nil
+}
+
+func (tx *MockupKVTx) add(ops ...mockupKVOp) error {
+	if err := tx.ctx.Err(); err != nil {
+		return err
+	}
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+	if tx.done {
+		return s3xErrors.ErrTxDone
+	}
+	tx.ops = append(tx.ops, ops...)
+	return nil
+}
+
+// Put - post single key/value pair
+func (tx *MockupKVTx) Put(key string, value *bytes.Buffer, contentType string) error {
+	return tx.add(mockupKVOp{key: key, value: value.String()})
+}
+
+// Delete - delete single key
+func (tx *MockupKVTx) Delete(key string) error {
+	return tx.add(mockupKVOp{key: key, delete: true})
+}
+
+// PutMap - post key/value map, values are stored JSON encoded
+func (tx *MockupKVTx) PutMap(values s3xApi.S3xKVMap) error {
+	var ops []mockupKVOp
+	for key, value := range values {
+		valueMapByte, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		ops = append(ops, mockupKVOp{key: key, value: string(valueMapByte)})
+	}
+	return tx.add(ops...)
+}
+
+// DeleteMap - delete keys of key/value map
+func (tx *MockupKVTx) DeleteMap(values s3xApi.S3xKVMap) error {
+	var ops []mockupKVOp
+	for key := range values {
+		ops = append(ops, mockupKVOp{key: key, delete: true})
+	}
+	return tx.add(ops...)
+}
+
+// PutJSON - post key/value pairs defined by json
+func (tx *MockupKVTx) PutJSON(keyValueJSON string) error {
+	var result map[string]interface{}
+	err := json.Unmarshal([]byte(keyValueJSON), &result)
+	if err != nil {
+		return fmt.Errorf("Unmarshal error %v", err)
+	}
+	var ops []mockupKVOp
+	for key, value := range result {
+		ops = append(ops, mockupKVOp{key: key, value: fmt.Sprintf("%v", value)})
+	}
+	return tx.add(ops...)
+}
+
+// PutCSV - post key/value pairs presented like csv
+func (tx *MockupKVTx) PutCSV(keyValueCSV string) error {
+	var ops []mockupKVOp
+	for _, s := range strings.Split(keyValueCSV, "\n") {
+		kv := strings.Split(s, ";")
+		if len(kv) < 2 {
+			continue
+		}
+		ops = append(ops, mockupKVOp{key: kv[0], value: kv[1]})
+	}
+	return tx.add(ops...)
+}
+
+// Commit - apply transaction updates in order
+func (tx *MockupKVTx) Commit() error {
+	if err := tx.ctx.Err(); err != nil {
+		return err
+	}
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+	if tx.done {
+		return s3xErrors.ErrTxDone
+	}
+	tx.done = true
+
+	tx.mockup.lock.Lock()
+	defer tx.mockup.lock.Unlock()
+
+	uri := tx.bucket + "/" + tx.object
+	o, exists := tx.mockup.Objects[uri]
+	if !exists {
+		return fmt.Errorf("Object %s/%s: %w", tx.bucket, tx.object, s3xErrors.ErrObjectNotExist)
+	}
+	for _, op := range tx.ops {
+		if op.delete {
+			delete(o.KeyValue, op.key)
+		} else {
+			o.KeyValue[op.key] = op.value
+		}
+	}
+	o.Modified = time.Now()
+	o.Generation++
+	tx.mockup.Objects[uri] = o
+	tx.ops = nil
+	return keyValueSync(tx.mockup)
+}
+
+// Rollback - discard transaction updates
+func (tx *MockupKVTx) Rollback() error {
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+	if tx.done {
+		return s3xErrors.ErrTxDone
+	}
+	tx.done = true
+	tx.ops = nil
+	return nil
+}